@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/accrualsim"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/logger"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	simConfig, err := config.NewAccrualSimConfig(os.Args[1:])
+
+	if err != nil {
+		logger.Log.Fatal("Config initialized failed: %v", zap.Error(err))
+	}
+
+	if err := logger.Initialized(simConfig.LogLevel); err != nil {
+		logger.Log.Fatal("Logger initialized failed: %v", zap.Error(err))
+	}
+	defer logger.Log.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	simulator, err := accrualsim.NewSimulator(simConfig, logger.Log)
+	if err != nil {
+		logger.Log.Fatal("Unable to config accrual simulator", zap.Error(err))
+	}
+
+	logger.Log.Info(fmt.Sprintf("Starting accrual simulator on %s ...", simConfig.Address))
+
+	if err := simulator.Run(ctx); err != nil {
+		logger.Log.Fatal("Accrual simulator start failed: %v", zap.Error(err))
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"os"
+)
+
+const configUsage = "usage: gophermart config print [flags]"
+
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+
+	effectiveConfig, err := config.Load(args[1:])
+	if err != nil {
+		return err
+	}
+
+	if err := effectiveConfig.Print(os.Stdout); err != nil {
+		return err
+	}
+
+	return effectiveConfig.Validate()
+}
+
+func isConfigCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == "config"
+}
+
+func exitOnConfigError(err error) {
+	if config.IsHelp(err) {
+		fmt.Println(err)
+		os.Exit(0)
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
@@ -13,10 +13,40 @@ import (
 )
 
 func main() {
-	serverConfig, err := config.NewConfig(os.Args[1:])
+	if isMigrateCommand() {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := runMigrate(ctx, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if isReconcileCommand() {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := runReconcile(ctx, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if isConfigCommand() {
+		if err := runConfig(os.Args[2:]); err != nil {
+			exitOnConfigError(err)
+		}
+		return
+	}
 
+	serverConfig, err := config.NewConfig(os.Args[1:])
 	if err != nil {
-		logger.Log.Fatal("Config initialized failed: %v", zap.Error(err))
+		exitOnConfigError(err)
 	}
 
 	if err := logger.Initialized(serverConfig.LogLevel); err != nil {
@@ -32,6 +62,11 @@ func main() {
 		logger.Log.Fatal("Unable to config Server", zap.Error(err))
 	}
 
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	go reloadOnHangup(ctx, hangup, app)
+
 	logger.Log.Info(fmt.Sprintf("Starting Gophermart app on %s ...", serverConfig.Address))
 
 	if err := app.Run(ctx); err != nil {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"os"
+)
+
+const migrateUsage = "usage: gophermart migrate up|down|status|version [flags]"
+
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 || !postgre.IsMigrateCommand(args[0]) {
+		return errors.New(migrateUsage)
+	}
+
+	migrateConfig, err := config.NewConfig(args[1:])
+	if err != nil {
+		return err
+	}
+
+	if migrateConfig.DatabaseConnection == "" {
+		return errors.New("database connection string is required, use -d or DATABASE_URI")
+	}
+
+	return postgre.Migrate(ctx, migrateConfig.DatabaseConnection, args[0])
+}
+
+func isMigrateCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == "migrate"
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/app"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/logger"
+	"os"
+)
+
+func runReconcile(ctx context.Context, args []string) error {
+	reconcileConfig, err := config.NewConfig(args)
+	if err != nil {
+		return err
+	}
+
+	if reconcileConfig.DatabaseConnection == "" {
+		return errors.New("database connection string is required, use -d or DATABASE_URI")
+	}
+
+	if err := logger.Initialized(reconcileConfig.LogLevel); err != nil {
+		return err
+	}
+	defer logger.Log.Sync()
+
+	gophermartApp, err := app.NewGophermartApp(ctx, reconcileConfig, logger.Log)
+	if err != nil {
+		return err
+	}
+
+	report, path, err := gophermartApp.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("checked %d orders, %d failed, %d mismatches, %d corrected\n",
+		report.Checked, report.Failed, len(report.Mismatches), report.Corrected)
+	if path != "" {
+		fmt.Printf("report written to %s\n", path)
+	}
+	return nil
+}
+
+func isReconcileCommand() bool {
+	return len(os.Args) > 1 && os.Args[1] == "reconcile"
+}
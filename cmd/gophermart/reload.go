@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/app"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/logger"
+	"go.uber.org/zap"
+	"os"
+)
+
+func reloadOnHangup(ctx context.Context, hangup <-chan os.Signal, gophermartApp *app.GophermartApp) {
+	for {
+		select {
+		case <-hangup:
+			reloadedConfig, err := config.NewConfig(os.Args[1:])
+			if err != nil {
+				logger.Log.Error("Config reload failed, keeping current settings", zap.Error(err))
+				continue
+			}
+
+			if err := logger.SetLevel(reloadedConfig.LogLevel); err != nil {
+				logger.Log.Error("Log level reload failed", zap.Error(err))
+			}
+			gophermartApp.Reload(reloadedConfig)
+			logger.Log.Info("Config reloaded")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
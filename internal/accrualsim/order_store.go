@@ -0,0 +1,58 @@
+package accrualsim
+
+import (
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"sync"
+	"time"
+)
+
+type simulatedOrder struct {
+	number       string
+	registeredAt time.Time
+	finalStatus  string
+	accrual      float64
+}
+
+type orderStore struct {
+	mu     sync.RWMutex
+	orders map[string]simulatedOrder
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{orders: make(map[string]simulatedOrder)}
+}
+
+func (s *orderStore) get(number string) (simulatedOrder, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	order, ok := s.orders[number]
+	return order, ok
+}
+
+func (s *orderStore) add(order simulatedOrder) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.orders[order.number]; exists {
+		return false
+	}
+
+	s.orders[order.number] = order
+	return true
+}
+
+func (o simulatedOrder) snapshot(now time.Time, registeredDelay, processingDelay time.Duration) view.AccrualResponse {
+	elapsed := now.Sub(o.registeredAt)
+
+	switch {
+	case elapsed < registeredDelay:
+		return view.AccrualResponse{Order: o.number, Status: view.AccrualOrderRegisteredStatus}
+	case elapsed < registeredDelay+processingDelay:
+		return view.AccrualResponse{Order: o.number, Status: view.AccrualOrderProcessingStatus}
+	case o.finalStatus == view.AccrualOrderProcessedStatus:
+		return view.AccrualResponse{Order: o.number, Status: o.finalStatus, Accrual: o.accrual}
+	default:
+		return view.AccrualResponse{Order: o.number, Status: o.finalStatus}
+	}
+}
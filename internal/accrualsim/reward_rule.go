@@ -0,0 +1,76 @@
+package accrualsim
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	RewardTypePercent = "%"
+	RewardTypePoints  = "pt"
+)
+
+type RewardRule struct {
+	Match      string  `json:"match" binding:"required"`
+	Reward     float64 `json:"reward" binding:"required,gt=0"`
+	RewardType string  `json:"reward_type" binding:"required"`
+}
+
+type Good struct {
+	Description string  `json:"description" binding:"required"`
+	Price       float64 `json:"price" binding:"gte=0"`
+}
+
+func (r RewardRule) Validate() error {
+	if strings.TrimSpace(r.Match) == "" {
+		return fmt.Errorf("reward rule match is empty")
+	}
+
+	if r.RewardType != RewardTypePercent && r.RewardType != RewardTypePoints {
+		return fmt.Errorf("unsupported reward type %q", r.RewardType)
+	}
+
+	return nil
+}
+
+func (r RewardRule) matches(good Good) bool {
+	return strings.Contains(strings.ToLower(good.Description), strings.ToLower(r.Match))
+}
+
+func (r RewardRule) rewardFor(good Good) float64 {
+	if r.RewardType == RewardTypePercent {
+		return good.Price * r.Reward / 100
+	}
+	return r.Reward
+}
+
+func ParseRewardRule(value string) (RewardRule, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return RewardRule{}, fmt.Errorf("reward rule %q must look like match:reward:type", value)
+	}
+
+	reward, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return RewardRule{}, fmt.Errorf("reward rule %q has invalid reward: %w", value, err)
+	}
+
+	rule := RewardRule{Match: parts[0], Reward: reward, RewardType: parts[2]}
+	return rule, rule.Validate()
+}
+
+func calculateAccrual(rules []RewardRule, goods []Good) (accrual float64, ok bool) {
+	for _, good := range goods {
+		for _, rule := range rules {
+			if rule.matches(good) {
+				accrual += rule.rewardFor(good)
+				ok = true
+				break
+			}
+		}
+	}
+
+	return math.Round(accrual*100) / 100, ok
+}
@@ -0,0 +1,183 @@
+package accrualsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ShiraazMoollatjie/goluhn"
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"go.uber.org/zap"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const rateLimitWindow = time.Minute
+
+type OrderRegisterCommand struct {
+	Order string `json:"order" binding:"required"`
+	Goods []Good `json:"goods" binding:"required,dive"`
+}
+
+type Simulator struct {
+	cfg         *config.AccrualSimConfig
+	log         zap.Logger
+	now         func() time.Time
+	orders      *orderStore
+	rulesMu     sync.RWMutex
+	rules       []RewardRule
+	rateLimiter *rateLimiter
+}
+
+func NewSimulator(cfg *config.AccrualSimConfig, log *zap.Logger) (*Simulator, error) {
+	rules := make([]RewardRule, 0, len(cfg.RewardRules))
+	for _, value := range cfg.RewardRules {
+		rule, err := ParseRewardRule(value)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return &Simulator{
+		cfg:         cfg,
+		log:         *log,
+		now:         time.Now,
+		orders:      newOrderStore(),
+		rules:       rules,
+		rateLimiter: newRateLimiter(cfg.RequestsPerMinute, rateLimitWindow),
+	}, nil
+}
+
+func (s *Simulator) Router() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	router.GET("/api/orders/:number", s.HandleGetOrder)
+	router.POST("/api/orders", s.HandleRegisterOrder)
+	router.POST("/api/goods", s.HandleRegisterReward)
+
+	return router
+}
+
+func (s *Simulator) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    s.cfg.Address,
+		Handler: s.Router(),
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Fatal("Accrual simulator error", zap.Error(err))
+		}
+	}()
+
+	s.log.Info("Accrual simulator started")
+
+	<-ctx.Done()
+	s.log.Info("Shutting down accrual simulator...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.GracefulShutdownInterval)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("accrual simulator forced to shutdown: %w", err)
+	}
+
+	s.log.Info("Accrual simulator exited properly")
+	return nil
+}
+
+func (s *Simulator) HandleGetOrder(ginContext *gin.Context) {
+	now := s.now()
+
+	if allowed, retryAfter := s.rateLimiter.allow(now); !allowed {
+		ginContext.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		ginContext.String(http.StatusTooManyRequests, "No more than %d requests per minute allowed", s.cfg.RequestsPerMinute)
+		return
+	}
+
+	number := ginContext.Param("number")
+	order, ok := s.orders.get(number)
+	if !ok && s.cfg.AutoRegister && goluhn.Validate(number) == nil {
+		s.orders.add(simulatedOrder{
+			number:       number,
+			registeredAt: now,
+			finalStatus:  view.AccrualOrderProcessedStatus,
+			accrual:      s.cfg.DefaultAccrual,
+		})
+		order, ok = s.orders.get(number)
+	}
+
+	if !ok {
+		ginContext.Status(http.StatusNoContent)
+		return
+	}
+
+	ginContext.JSON(http.StatusOK, order.snapshot(now, s.cfg.RegisteredDelay, s.cfg.ProcessingDelay))
+}
+
+func (s *Simulator) HandleRegisterOrder(ginContext *gin.Context) {
+	var registerCommand OrderRegisterCommand
+	if err := ginContext.ShouldBindJSON(&registerCommand); err != nil {
+		s.log.Error(fmt.Sprintf("Invalid order registration: %s ", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	order := simulatedOrder{
+		number:       registerCommand.Order,
+		registeredAt: s.now(),
+		finalStatus:  view.AccrualOrderInvalidStatus,
+	}
+
+	if goluhn.Validate(registerCommand.Order) == nil {
+		s.rulesMu.RLock()
+		accrual, matched := calculateAccrual(s.rules, registerCommand.Goods)
+		s.rulesMu.RUnlock()
+
+		if matched {
+			order.finalStatus = view.AccrualOrderProcessedStatus
+			order.accrual = accrual
+		}
+	}
+
+	if !s.orders.add(order) {
+		ginContext.JSON(http.StatusConflict, gin.H{"error": "Order already registered"})
+		return
+	}
+
+	s.log.Info(fmt.Sprintf("Order %s registered, final status %s", order.number, order.finalStatus))
+	ginContext.Status(http.StatusAccepted)
+}
+
+func (s *Simulator) HandleRegisterReward(ginContext *gin.Context) {
+	var rule RewardRule
+	if err := ginContext.ShouldBindJSON(&rule); err != nil {
+		s.log.Error(fmt.Sprintf("Invalid reward rule: %s ", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := rule.Validate(); err != nil {
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.rulesMu.Lock()
+	defer s.rulesMu.Unlock()
+
+	for _, existing := range s.rules {
+		if existing.Match == rule.Match {
+			ginContext.JSON(http.StatusConflict, gin.H{"error": "Reward rule already registered"})
+			return
+		}
+	}
+
+	s.rules = append(s.rules, rule)
+	ginContext.Status(http.StatusOK)
+}
@@ -0,0 +1,161 @@
+package accrualsim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSimulator(t *testing.T, cfg *config.AccrualSimConfig, now *time.Time) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	simulator, err := NewSimulator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	simulator.now = func() time.Time { return *now }
+
+	return simulator.Router()
+}
+
+func doRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSimulator_OrderLifecycle(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	router := newTestSimulator(t, &config.AccrualSimConfig{
+		RegisteredDelay: time.Second,
+		ProcessingDelay: 2 * time.Second,
+		RewardRules:     []string{"Bork:10:%", "Chair:15:pt"},
+	}, &now)
+
+	rec := doRequest(router, http.MethodPost, "/api/orders",
+		`{"order":"12345678903","goods":[{"description":"Чайник Bork","price":7000},{"description":"Office chair","price":500}]}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	steps := []struct {
+		elapsed time.Duration
+		status  string
+		accrual float64
+	}{
+		{0, view.AccrualOrderRegisteredStatus, 0},
+		{time.Second, view.AccrualOrderProcessingStatus, 0},
+		{3 * time.Second, view.AccrualOrderProcessedStatus, 715},
+	}
+
+	start := now
+	for _, step := range steps {
+		now = start.Add(step.elapsed)
+
+		rec := doRequest(router, http.MethodGet, "/api/orders/12345678903", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response view.AccrualResponse
+		require.NoError(t, easyjson.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "12345678903", response.Order)
+		assert.Equal(t, step.status, response.Status)
+		assert.Equal(t, step.accrual, response.Accrual)
+	}
+
+	rec = doRequest(router, http.MethodPost, "/api/orders", `{"order":"12345678903","goods":[]}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSimulator_InvalidOrders(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	router := newTestSimulator(t, &config.AccrualSimConfig{RewardRules: []string{"Bork:10:%"}}, &now)
+
+	require.Equal(t, http.StatusAccepted, doRequest(router, http.MethodPost, "/api/orders",
+		`{"order":"79927398713","goods":[{"description":"Table","price":100}]}`).Code)
+	require.Equal(t, http.StatusAccepted, doRequest(router, http.MethodPost, "/api/orders",
+		`{"order":"12345","goods":[{"description":"Bork","price":100}]}`).Code)
+
+	for _, number := range []string{"79927398713", "12345"} {
+		rec := doRequest(router, http.MethodGet, "/api/orders/"+number, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"order":"`+number+`","status":"INVALID"}`, rec.Body.String())
+	}
+}
+
+func TestSimulator_UnknownOrder(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("answers no content", func(t *testing.T) {
+		router := newTestSimulator(t, &config.AccrualSimConfig{}, &now)
+
+		rec := doRequest(router, http.MethodGet, "/api/orders/12345678903", "")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("auto registers valid numbers", func(t *testing.T) {
+		router := newTestSimulator(t, &config.AccrualSimConfig{AutoRegister: true, DefaultAccrual: 42}, &now)
+
+		rec := doRequest(router, http.MethodGet, "/api/orders/12345678903", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"order":"12345678903","status":"PROCESSED","accrual":42}`, rec.Body.String())
+
+		rec = doRequest(router, http.MethodGet, "/api/orders/12345", "")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
+
+func TestSimulator_RateLimit(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	router := newTestSimulator(t, &config.AccrualSimConfig{RequestsPerMinute: 2}, &now)
+
+	assert.Equal(t, http.StatusNoContent, doRequest(router, http.MethodGet, "/api/orders/1", "").Code)
+	assert.Equal(t, http.StatusNoContent, doRequest(router, http.MethodGet, "/api/orders/1", "").Code)
+
+	now = now.Add(15 * time.Second)
+	rec := doRequest(router, http.MethodGet, "/api/orders/1", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "45", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "No more than 2 requests per minute allowed", rec.Body.String())
+
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, http.StatusNoContent, doRequest(router, http.MethodGet, "/api/orders/1", "").Code)
+}
+
+func TestSimulator_RegisterReward(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	router := newTestSimulator(t, &config.AccrualSimConfig{}, &now)
+
+	assert.Equal(t, http.StatusOK, doRequest(router, http.MethodPost, "/api/goods",
+		`{"match":"Bork","reward":10,"reward_type":"%"}`).Code)
+	assert.Equal(t, http.StatusConflict, doRequest(router, http.MethodPost, "/api/goods",
+		`{"match":"Bork","reward":5,"reward_type":"pt"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, doRequest(router, http.MethodPost, "/api/goods",
+		`{"match":"Table","reward":5,"reward_type":"coins"}`).Code)
+
+	require.Equal(t, http.StatusAccepted, doRequest(router, http.MethodPost, "/api/orders",
+		`{"order":"12345678903","goods":[{"description":"bork kettle","price":250}]}`).Code)
+
+	rec := doRequest(router, http.MethodGet, "/api/orders/12345678903", "")
+	assert.JSONEq(t, `{"order":"12345678903","status":"PROCESSED","accrual":25}`, rec.Body.String())
+}
+
+func TestParseRewardRule(t *testing.T) {
+	rule, err := ParseRewardRule("Bork:10:%")
+	require.NoError(t, err)
+	assert.Equal(t, RewardRule{Match: "Bork", Reward: 10, RewardType: RewardTypePercent}, rule)
+
+	for _, value := range []string{"Bork", "Bork:ten:%", "Bork:10:coins", ":10:pt"} {
+		_, err := ParseRewardRule(value)
+		assert.Error(t, err, value)
+	}
+}
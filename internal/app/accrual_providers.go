@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/client"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/service"
+	"go.uber.org/zap"
+	"strings"
+)
+
+func newAccrualProviders(cfg *config.Config, log *zap.Logger) (*service.AccrualProviderRegistry, error) {
+	providers := service.NewAccrualProviderRegistry(newOrderStatusClient(cfg, service.DefaultAccrualProvider, cfg.AccrualSystemAddress, log))
+
+	for _, spec := range cfg.AccrualProviders {
+		name, address, rule, err := parseAccrualProvider(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := providers.Register(name, rule, newOrderStatusClient(cfg, name, address, log)); err != nil {
+			return nil, err
+		}
+		log.Info("Accrual provider registered",
+			zap.String("provider", name),
+			zap.String("address", address),
+			zap.String("rule", rule),
+		)
+	}
+
+	return providers, nil
+}
+
+func newOrderStatusClient(cfg *config.Config, name string, address string, log *zap.Logger) *client.OrderStatusClient {
+	return client.NewOrderStatusClient(
+		address,
+		cfg.AccrualTimeout,
+		cfg.AccrualMaxRetries,
+		cfg.AccrualRetryWait,
+		cfg.AccrualRetryMaxWait,
+		client.NewCircuitBreaker("accrual:"+name, cfg.AccrualBreakerThreshold, cfg.AccrualBreakerOpenTimeout, log),
+		client.NewRateLimiter("accrual:"+name, cfg.AccrualRequestsPerMinute, log),
+	)
+}
+
+func parseAccrualProvider(spec string) (string, string, string, error) {
+	parts := strings.SplitN(spec, "|", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid accrual provider %q, expected name|address|rule", spec)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -8,113 +8,333 @@ import (
 	"github.com/ruslanDantsov/gophermart/internal/client"
 	"github.com/ruslanDantsov/gophermart/internal/config"
 	"github.com/ruslanDantsov/gophermart/internal/handler"
+	"github.com/ruslanDantsov/gophermart/internal/handler/accrual"
+	"github.com/ruslanDantsov/gophermart/internal/handler/admin"
 	"github.com/ruslanDantsov/gophermart/internal/handler/balance"
+	"github.com/ruslanDantsov/gophermart/internal/handler/health"
 	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
 	"github.com/ruslanDantsov/gophermart/internal/handler/order"
+	"github.com/ruslanDantsov/gophermart/internal/handler/profile"
+	"github.com/ruslanDantsov/gophermart/internal/handler/transfer"
 	"github.com/ruslanDantsov/gophermart/internal/handler/user"
+	"github.com/ruslanDantsov/gophermart/internal/handler/webhook"
 	"github.com/ruslanDantsov/gophermart/internal/handler/withdraw"
-	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
-	"github.com/ruslanDantsov/gophermart/internal/repository"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/pubsub"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"github.com/ruslanDantsov/gophermart/internal/service"
 	"go.uber.org/zap"
 	"net/http"
+	"sync"
 	"time"
 )
 
 type GophermartApp struct {
-	cfg                 *config.Config
-	logger              *zap.Logger
-	accrualOrderService *service.AccrualOrderService
-	storage             *postgre.PostgreStorage
-	commonHandler       *handler.CommonHandler
-	userHandler         *user.UserHandler
-	orderHandler        *order.OrderHandler
-	balanceHandler      *balance.BalanceHandler
-	withdrawHandler     *withdraw.WithdrawHandler
+	cfgMu                    sync.Mutex
+	cfg                      *config.Config
+	logger                   *zap.Logger
+	authService              *service.JWTService
+	userService              *service.UserService
+	pollIntervals            chan time.Duration
+	accrualOrderService      *service.AccrualOrderService
+	webhookDispatcherService *service.WebhookDispatcherService
+	expirationService        *service.ExpirationService
+	withdrawHoldService      *service.WithdrawHoldService
+	tierService              *service.TierService
+	reconciliationService    *service.ReconciliationService
+	rateLimitService         *service.RateLimitService
+	orderEventBridge         orderEventBridge
+	commonHandler            *handler.CommonHandler
+	userHandler              *user.UserHandler
+	orderHandler             *order.OrderHandler
+	balanceHandler           *balance.BalanceHandler
+	withdrawHandler          *withdraw.WithdrawHandler
+	transferHandler          *transfer.TransferHandler
+	profileHandler           *profile.ProfileHandler
+	webhookHandler           *webhook.WebhookHandler
+	adminHandler             *admin.AdminHandler
+	healthHandler            *health.HealthHandler
+	accrualCallbackHandler   *accrual.AccrualCallbackHandler
 }
 
 func NewGophermartApp(ctx context.Context, cfg *config.Config, log *zap.Logger) (*GophermartApp, error) {
-	storage, err := postgre.NewPostgreStorage(ctx, log, cfg.DatabaseConnection)
+	orderEventBroker := pubsub.NewOrderEventBroker()
+
+	backend, err := newStorageBackend(ctx, cfg, orderEventBroker, log)
 	if err != nil {
 		return nil, err
 	}
 
-	userRepository := repository.NewUserRepository(storage)
 	passwordService := &service.PasswordService{}
-	userService := service.NewUserService(userRepository, passwordService)
+	userService := service.NewUserService(backend.user, passwordService)
 
 	commonHandler := handler.NewCommonHandler(log)
 
 	authService := service.NewAuthService(cfg.JWTSecret)
 	userHandler := user.NewUserHandler(log, userService, authService)
 
-	orderRepository := repository.NewOrderRepository(storage)
-	orderService := service.NewOrderService(orderRepository, storage)
-	orderHandler := order.NewOrderHandler(log, orderService, orderService)
+	tierService := service.NewTierService(
+		backend.txManager,
+		backend.userTier,
+		backend.outbox,
+		service.SystemClock{},
+		cfg.TierWindow,
+		[]business.TierLevel{
+			{Name: entity.TierBronze, Threshold: cfg.TierBronzeThreshold},
+			{Name: entity.TierSilver, Threshold: cfg.TierSilverThreshold},
+			{Name: entity.TierGold, Threshold: cfg.TierGoldThreshold},
+		},
+		log,
+	)
+	profileHandler := profile.NewProfileHandler(log, tierService)
+
+	orderService := service.NewOrderService(backend.order, backend.txManager, backend.orderEvents, backend.outbox, backend.orderStatusHistory, tierService)
+	orderHandler := order.NewOrderHandler(log, orderService, orderService, orderEventBroker)
+
+	expirationService := service.NewExpirationService(
+		backend.txManager,
+		backend.expiration,
+		backend.user,
+		backend.balanceAdjustment,
+		backend.outbox,
+		service.SystemClock{},
+		cfg.PointsExpirationMonths,
+		cfg.PointsExpiringSoonWindow,
+		log,
+	)
+
+	balanceService := service.NewBalanceService(
+		backend.order,
+		backend.withdraw,
+		backend.balanceAdjustment,
+		backend.withdrawHold,
+		backend.transfer,
+		backend.statement,
+		expirationService,
+	)
+	balanceHandler := balance.NewBalanceHandler(log, balanceService, balanceService)
+	balanceAdjustmentService := service.NewBalanceAdjustmentService(backend.balanceAdjustment, backend.user, balanceService, backend.outbox, backend.txManager)
+
+	withdrawService := service.NewWithdrawService(backend.withdraw, backend.withdrawHold, balanceService, backend.user, backend.outbox, backend.txManager)
+	withdrawHoldService := service.NewWithdrawHoldService(
+		backend.withdrawHold,
+		backend.withdraw,
+		balanceService,
+		backend.user,
+		backend.outbox,
+		backend.txManager,
+		service.SystemClock{},
+		cfg.WithdrawHoldTTL,
+		log,
+	)
+	withdrawHandler := withdraw.NewWithdrawHandler(log, withdrawService, withdrawService, withdrawHoldService)
+
+	transferService := service.NewTransferService(
+		backend.transfer,
+		backend.user,
+		backend.user,
+		balanceService,
+		backend.outbox,
+		backend.txManager,
+		service.SystemClock{},
+		cfg.TransferDailyLimit,
+	)
+	transferHandler := transfer.NewTransferHandler(log, transferService)
 
-	withdrawRepository := repository.NewWithdrawnRepository(storage)
-	withdrawService := service.NewWithdrawService(orderService, withdrawRepository, orderRepository, storage)
-	withdrawHandler := withdraw.NewWithdrawHandler(log, withdrawService, withdrawService)
+	webhookService := service.NewWebhookService(backend.webhook)
+	webhookHandler := webhook.NewWebhookHandler(log, webhookService)
 
-	balanceService := service.NewBalanceService(orderRepository, withdrawRepository)
-	balanceHandler := balance.NewBalanceHandler(log, balanceService)
+	webhookClient := client.NewWebhookClient(cfg.WebhookTimeout)
+	webhookDispatcherService := service.NewWebhookDispatcherService(
+		backend.txManager,
+		backend.outbox,
+		backend.webhook,
+		backend.webhookDelivery,
+		webhookClient,
+		cfg.WebhookMaxAttempts,
+		cfg.WebhookBackoff,
+		cfg.WebhookMaxBackoff,
+		log,
+	)
 
-	orderStatusClient := client.NewOrderStatusClient(cfg.AccrualSystemAddress)
-	accrualOrderService := service.NewAccrualOrderService(orderService, orderStatusClient, log)
+	accrualProviders, err := newAccrualProviders(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	accrualOrderService := service.NewAccrualOrderService(orderService, accrualProviders, cfg.AccrualWorkers, log)
+
+	reconciliationService := service.NewReconciliationService(
+		backend.txManager,
+		backend.reconciliation,
+		accrualProviders,
+		backend.user,
+		balanceService,
+		backend.balanceAdjustment,
+		backend.outbox,
+		service.SystemClock{},
+		log,
+	)
+
+	rateLimitService, err := newRateLimitService(cfg, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	healthHandler := health.NewHealthHandler(log, accrualOrderService)
+	accrualCallbackHandler := accrual.NewAccrualCallbackHandler(log, orderService)
+
+	orderTimelineService := service.NewOrderTimelineService(backend.order, backend.orderStatusHistory)
+	adminHandler := admin.NewAdminHandler(
+		log,
+		userService,
+		orderService,
+		withdrawService,
+		balanceService,
+		balanceService,
+		balanceAdjustmentService,
+		withdrawService,
+		orderTimelineService,
+		accrualOrderService,
+		accrualOrderService,
+	)
+
+	grantedLogins, err := userService.GrantAdminRole(ctx, cfg.AdminLogins)
+	if err != nil {
+		return nil, err
+	}
+	if len(grantedLogins) > 0 {
+		log.Info("Admin role granted", zap.Strings("logins", grantedLogins))
+	}
 
 	return &GophermartApp{
-		cfg:                 cfg,
-		logger:              log,
-		storage:             storage,
-		commonHandler:       commonHandler,
-		userHandler:         userHandler,
-		orderHandler:        orderHandler,
-		balanceHandler:      balanceHandler,
-		withdrawHandler:     withdrawHandler,
-		accrualOrderService: accrualOrderService,
+		cfg:                      cfg,
+		logger:                   log,
+		authService:              authService,
+		userService:              userService,
+		pollIntervals:            make(chan time.Duration, 1),
+		orderEventBridge:         backend.orderEvents,
+		commonHandler:            commonHandler,
+		userHandler:              userHandler,
+		orderHandler:             orderHandler,
+		balanceHandler:           balanceHandler,
+		withdrawHandler:          withdrawHandler,
+		transferHandler:          transferHandler,
+		profileHandler:           profileHandler,
+		webhookHandler:           webhookHandler,
+		adminHandler:             adminHandler,
+		healthHandler:            healthHandler,
+		accrualCallbackHandler:   accrualCallbackHandler,
+		accrualOrderService:      accrualOrderService,
+		webhookDispatcherService: webhookDispatcherService,
+		expirationService:        expirationService,
+		reconciliationService:    reconciliationService,
+		rateLimitService:         rateLimitService,
+		withdrawHoldService:      withdrawHoldService,
+		tierService:              tierService,
 	}, nil
 }
 
 func (app *GophermartApp) Run(ctx context.Context) error {
+	cfg := app.config()
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	rateLimit := middleware.RateLimitMiddleware(app.rateLimitService, app.logger)
 
-	router.POST("/api/user/register", app.userHandler.HandleRegisterUser)
-	router.POST("/api/user/login", app.userHandler.HandleAuthentication)
+	public := router.Group("/")
+	public.Use(rateLimit)
+
+	public.GET("/api/health", app.healthHandler.HandleGetHealth)
+	public.POST("/api/user/register", app.userHandler.HandleRegisterUser)
+	public.POST("/api/user/login", app.userHandler.HandleAuthentication)
 
 	protected := router.Group("/")
-	protected.Use(middleware.AuthMiddleware(app.cfg.JWTSecret, app.logger))
+	protected.Use(middleware.AuthMiddleware(app.authService, app.logger), rateLimit)
+
+	protected.GET("/api/user/profile", app.profileHandler.HandleGetProfile)
 
 	protected.POST("/api/user/orders", app.orderHandler.HandleRegisterOrder)
 	protected.GET("/api/user/orders", app.orderHandler.HandleGetOrders)
+	protected.GET("/api/user/orders/events", app.orderHandler.HandleOrderEvents)
 
 	protected.GET("/api/user/balance", app.balanceHandler.HandleGetBalance)
+	protected.GET("/api/user/balance/statement", app.balanceHandler.HandleGetStatement)
 
 	protected.POST("/api/user/balance/withdraw", app.withdrawHandler.HandleAddingWithdraw)
+	protected.POST("/api/user/balance/transfer", app.transferHandler.HandleAddingTransfer)
 	protected.GET("/api/user/withdrawals", app.withdrawHandler.HandleGetWithdraws)
+	protected.POST("/api/user/balance/holds", app.withdrawHandler.HandleAddingHold)
+	protected.GET("/api/user/balance/holds", app.withdrawHandler.HandleGetHolds)
+	protected.POST("/api/user/balance/holds/:number/capture", app.withdrawHandler.HandleCapturingHold)
+	protected.POST("/api/user/balance/holds/:number/release", app.withdrawHandler.HandleReleasingHold)
+
+	protected.POST("/api/user/webhooks", app.webhookHandler.HandleAddingWebhook)
+	protected.GET("/api/user/webhooks", app.webhookHandler.HandleGetWebhooks)
+	protected.GET("/api/user/webhooks/:id", app.webhookHandler.HandleGetWebhook)
+	protected.PUT("/api/user/webhooks/:id", app.webhookHandler.HandleUpdatingWebhook)
+	protected.DELETE("/api/user/webhooks/:id", app.webhookHandler.HandleDeletingWebhook)
+
+	adminGroup := router.Group("/api/admin")
+	if cfg.TLSClientCAFile != "" {
+		adminGroup.Use(middleware.ClientCertMiddleware(app.logger))
+	}
+	adminGroup.Use(
+		middleware.AuthMiddleware(app.authService, app.logger),
+		middleware.RoleMiddleware(app.userService, app.logger, entity.UserRoleAdmin),
+		rateLimit,
+	)
+
+	adminGroup.GET("/users", app.adminHandler.HandleGetUsers)
+	adminGroup.PUT("/users/:id/role", app.adminHandler.HandleUpdatingUserRole)
+	adminGroup.GET("/users/:id/orders", app.adminHandler.HandleGetUserOrders)
+	adminGroup.GET("/users/:id/withdrawals", app.adminHandler.HandleGetUserWithdrawals)
+	adminGroup.GET("/users/:id/balance", app.adminHandler.HandleGetUserBalance)
+	adminGroup.GET("/users/:id/statement", app.adminHandler.HandleGetUserStatement)
+	adminGroup.POST("/users/:id/adjustments", app.adminHandler.HandleAddingBalanceAdjustment)
+	adminGroup.POST("/withdrawals/:number/reverse", app.adminHandler.HandleReversingWithdraw)
+	adminGroup.GET("/orders/:number/timeline", app.adminHandler.HandleGetOrderTimeline)
+	adminGroup.POST("/orders/:number/resync", app.adminHandler.HandleResyncOrder)
+	adminGroup.GET("/accrual/providers", app.adminHandler.HandleGetAccrualProviders)
+
+	if cfg.AccrualCallbackSecret != "" {
+		internalGroup := router.Group("/api/internal")
+		if cfg.TLSClientCAFile != "" {
+			internalGroup.Use(middleware.ClientCertMiddleware(app.logger))
+		}
+		internalGroup.Use(middleware.SignatureMiddleware(cfg.AccrualCallbackSecret, cfg.AccrualCallbackTolerance, app.logger), rateLimit)
+		internalGroup.POST("/accrual/callback", app.accrualCallbackHandler.HandleCallback)
+	}
 
 	router.NoRoute(app.commonHandler.HandleUnsupportedRequest)
 
-	srv := &http.Server{
-		Addr:    app.cfg.Address,
-		Handler: router,
+	router.UseH2C = cfg.H2C
+	srv, err := app.newHTTPServer(ctx, cfg, router.Handler())
+	if err != nil {
+		return err
 	}
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := serve(srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			app.logger.Fatal("Server error", zap.Error(err))
 		}
 	}()
 
 	app.logger.Info("Server started")
 
+	go app.orderEventBridge.Listen(ctx)
+
 	go func() {
-		ticker := time.NewTicker(app.cfg.ReportInterval)
+		ticker := time.NewTicker(pollInterval(cfg))
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
 				app.accrualOrderService.ProcessOrders(ctx)
+			case interval := <-app.pollIntervals:
+				ticker.Reset(interval)
 			case <-ctx.Done():
 				app.logger.Info("OrderStatusSyncService received shutdown signal")
 				return
@@ -122,10 +342,108 @@ func (app *GophermartApp) Run(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		ticker := time.NewTicker(cfg.WebhookDispatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				app.webhookDispatcherService.Dispatch(ctx)
+			case <-ctx.Done():
+				app.logger.Info("WebhookDispatcherService received shutdown signal")
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.WithdrawHoldSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				app.withdrawHoldService.ExpireHolds(ctx)
+			case <-ctx.Done():
+				app.logger.Info("WithdrawHoldService received shutdown signal")
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(cfg.TierSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				app.tierService.RefreshTiers(ctx)
+			case <-ctx.Done():
+				app.logger.Info("TierService received shutdown signal")
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(rateLimitSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := app.rateLimitService.DeleteExpired(ctx); err != nil {
+					app.logger.Error("Failed to delete expired rate limit counters", zap.Error(err))
+				}
+			case <-ctx.Done():
+				app.logger.Info("RateLimitService received shutdown signal")
+				return
+			}
+		}
+	}()
+
+	if app.expirationService.Enabled() {
+		go func() {
+			ticker := time.NewTicker(cfg.PointsExpirationInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					app.expirationService.ExpirePoints(ctx)
+				case <-ctx.Done():
+					app.logger.Info("ExpirationService received shutdown signal")
+					return
+				}
+			}
+		}()
+	}
+
+	if cfg.ReconcileInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.ReconcileInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					if _, _, err := app.Reconcile(ctx); err != nil {
+						app.logger.Error("Reconciliation failed", zap.Error(err))
+					}
+				case <-ctx.Done():
+					app.logger.Info("ReconciliationService received shutdown signal")
+					return
+				}
+			}
+		}()
+	}
+
 	<-ctx.Done()
 	app.logger.Info("Shutting down server...")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.cfg.GracefulShutdownInterval)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.GracefulShutdownInterval)
 	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
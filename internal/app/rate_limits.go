@@ -0,0 +1,31 @@
+package app
+
+import (
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/repository/inmemory"
+	"github.com/ruslanDantsov/gophermart/internal/service"
+	"time"
+)
+
+const rateLimitSweepInterval = time.Minute
+
+func newRateLimitService(cfg *config.Config, backend *storageBackend) (*service.RateLimitService, error) {
+	var rules []business.RateLimitRule
+	if !cfg.DisableRateLimits {
+		for _, spec := range cfg.RateLimits {
+			method, path, requests, window, err := config.ParseRateLimit(spec)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, business.RateLimitRule{Method: method, Path: path, Requests: requests, Window: window})
+		}
+	}
+
+	store := service.RateLimitStore(inmemory.NewRateLimitRepository())
+	if cfg.RateLimitStore == "postgres" {
+		store = backend.rateLimit
+	}
+
+	return service.NewRateLimitService(rules, store, service.SystemClock{}), nil
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"os"
+	"path/filepath"
+)
+
+func (app *GophermartApp) Reconcile(ctx context.Context) (*business.ReconciliationReport, string, error) {
+	cfg := app.config()
+	report, err := app.reconciliationService.Reconcile(ctx, cfg.ReconcileSampleSize, cfg.ReconcileApplyCorrections)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if cfg.ReconcileReportDir == "" {
+		return report, "", nil
+	}
+
+	path, err := writeReconciliationReport(cfg.ReconcileReportDir, report)
+	if err != nil {
+		return report, "", err
+	}
+
+	return report, path, nil
+}
+
+func writeReconciliationReport(dir string, report *business.ReconciliationReport) (string, error) {
+	viewModel := view.ReconciliationReportViewModel{
+		RunID:      report.RunID,
+		StartedAt:  report.StartedAt,
+		FinishedAt: report.FinishedAt,
+		Checked:    report.Checked,
+		Failed:     report.Failed,
+		Corrected:  report.Corrected,
+		Mismatches: make([]view.ReconciliationMismatchViewModel, len(report.Mismatches)),
+	}
+	for i, mismatch := range report.Mismatches {
+		viewModel.Mismatches[i] = view.ReconciliationMismatchViewModel{
+			OrderNumber:   mismatch.OrderNumber,
+			UserID:        mismatch.UserID,
+			StoredStatus:  mismatch.StoredStatus,
+			StoredAccrual: mismatch.StoredAccrual,
+			RemoteStatus:  mismatch.RemoteStatus,
+			RemoteAccrual: mismatch.RemoteAccrual,
+			Difference:    mismatch.Difference,
+			Corrected:     mismatch.Corrected,
+			DetectedAt:    mismatch.DetectedAt,
+		}
+	}
+
+	body, err := easyjson.Marshal(viewModel)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reconciliation report: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create reconciliation report directory: %w", err)
+	}
+
+	name := fmt.Sprintf("reconciliation-%s-%s.json", report.StartedAt.UTC().Format("20060102T150405Z"), report.RunID)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write reconciliation report: %w", err)
+	}
+
+	return path, nil
+}
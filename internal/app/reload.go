@@ -0,0 +1,62 @@
+package app
+
+import (
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (app *GophermartApp) Reload(next *config.Config) {
+	app.cfgMu.Lock()
+	defer app.cfgMu.Unlock()
+
+	if changed := app.cfg.NonReloadableChanges(next); len(changed) > 0 {
+		app.logger.Warn("Settings cannot be changed without restart, keeping current values",
+			zap.Strings("settings", changed))
+	}
+
+	applied := *app.cfg
+	applied.LogLevel = next.LogLevel
+	applied.JWTSecret = next.JWTSecret
+	applied.JWTSecretFile = next.JWTSecretFile
+	applied.ReportIntervalInSeconds = next.ReportIntervalInSeconds
+	applied.ReportInterval = next.ReportInterval
+	applied.AccrualFallbackIntervalInSeconds = next.AccrualFallbackIntervalInSeconds
+	applied.AccrualFallbackInterval = next.AccrualFallbackInterval
+	applied.AccrualWorkers = next.AccrualWorkers
+
+	if applied.JWTSecret != app.cfg.JWTSecret {
+		app.authService.RotateSecret(applied.JWTSecret)
+		app.logger.Info("JWT secret rotated, tokens signed with the previous secret are accepted until they expire")
+	}
+
+	if interval := pollInterval(&applied); interval != pollInterval(app.cfg) {
+		select {
+		case <-app.pollIntervals:
+		default:
+		}
+		app.pollIntervals <- interval
+		app.logger.Info("Accrual polling interval changed", zap.Duration("interval", interval))
+	}
+
+	if applied.AccrualWorkers != app.cfg.AccrualWorkers {
+		app.accrualOrderService.SetWorkers(applied.AccrualWorkers)
+		app.logger.Info("Accrual workers changed", zap.Int("workers", applied.AccrualWorkers))
+	}
+
+	app.cfg = &applied
+}
+
+func (app *GophermartApp) config() *config.Config {
+	app.cfgMu.Lock()
+	defer app.cfgMu.Unlock()
+
+	return app.cfg
+}
+
+func pollInterval(cfg *config.Config) time.Duration {
+	if cfg.AccrualCallbackSecret != "" {
+		return cfg.AccrualFallbackInterval
+	}
+	return cfg.ReportInterval
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/certificate"
+	"net/http"
+	"os"
+)
+
+func (app *GophermartApp) newHTTPServer(ctx context.Context, cfg *config.Config, handler http.Handler) (*http.Server, error) {
+	srv := &http.Server{
+		Addr:              cfg.Address,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	if cfg.TLSCertFile == "" {
+		return srv, nil
+	}
+
+	reloader, err := certificate.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, app.logger)
+	if err != nil {
+		return nil, fmt.Errorf("load TLS certificate: %w", err)
+	}
+	go reloader.Watch(ctx, cfg.TLSReloadInterval)
+
+	srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if cfg.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read TLS client CA: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in TLS client CA file")
+		}
+		srv.TLSConfig.ClientCAs = clientCAs
+		srv.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return srv, nil
+}
+
+func serve(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/pubsub"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/repository"
+	"github.com/ruslanDantsov/gophermart/internal/repository/inmemory"
+	"github.com/ruslanDantsov/gophermart/internal/service"
+	"go.uber.org/zap"
+)
+
+type orderEventBridge interface {
+	service.OrderEventPublisher
+	Listen(ctx context.Context)
+}
+
+type storageBackend struct {
+	txManager service.TxManager
+	user      interface {
+		service.UserRepository
+		service.UserLocker
+		service.UserIDFinder
+	}
+	order interface {
+		service.OrderRepository
+		service.OrderFinder
+		service.AccrualAggregatorRepository
+	}
+	orderStatusHistory interface {
+		service.OrderStatusHistoryRecorder
+		service.OrderStatusHistoryRepository
+	}
+	withdraw interface {
+		service.WithdrawRepository
+		service.WithdrawnAggregatorRepository
+	}
+	withdrawHold interface {
+		service.WithdrawHoldRepository
+		service.HeldAggregatorRepository
+	}
+	balanceAdjustment interface {
+		service.BalanceAdjustmentRepository
+		service.AdjustmentAggregatorRepository
+	}
+	transfer interface {
+		service.TransferRepository
+		service.TransferAggregatorRepository
+	}
+	statement  service.StatementRepository
+	expiration service.ExpirationRepository
+	userTier   service.UserTierRepository
+	outbox     interface {
+		service.OutboxRecorder
+		service.OutboxDispatchRepository
+	}
+	webhook         service.WebhookRepository
+	webhookDelivery service.WebhookDeliveryRepository
+	reconciliation  service.ReconciliationRepository
+	rateLimit       service.RateLimitStore
+	orderEvents     orderEventBridge
+}
+
+func newStorageBackend(ctx context.Context, cfg *config.Config, broker *pubsub.OrderEventBroker, log *zap.Logger) (*storageBackend, error) {
+	if cfg.DatabaseConnection == "" {
+		log.Warn("Database connection string is empty, data is kept in memory and lost on restart")
+		return newMemoryBackend(broker), nil
+	}
+
+	if !cfg.SkipMigrations {
+		if err := postgre.Migrate(ctx, cfg.DatabaseConnection, postgre.MigrateUp); err != nil {
+			return nil, err
+		}
+	}
+
+	storage, err := postgre.NewPostgreStorage(ctx, log, cfg.DatabaseConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storageBackend{
+		txManager:          storage,
+		user:               repository.NewUserRepository(storage),
+		order:              repository.NewOrderRepository(storage),
+		orderStatusHistory: repository.NewOrderStatusHistoryRepository(storage),
+		withdraw:           repository.NewWithdrawnRepository(storage),
+		withdrawHold:       repository.NewWithdrawHoldRepository(storage),
+		balanceAdjustment:  repository.NewBalanceAdjustmentRepository(storage),
+		transfer:           repository.NewTransferRepository(storage),
+		statement:          repository.NewStatementRepository(storage),
+		expiration:         repository.NewExpirationRepository(storage),
+		userTier:           repository.NewUserTierRepository(storage),
+		outbox:             repository.NewOutboxRepository(storage),
+		webhook:            repository.NewWebhookRepository(storage),
+		webhookDelivery:    repository.NewWebhookDeliveryRepository(storage),
+		reconciliation:     repository.NewReconciliationRepository(storage),
+		rateLimit:          repository.NewRateLimitRepository(storage),
+		orderEvents:        pubsub.NewPostgreNotifyBridge(storage, broker, log),
+	}, nil
+}
+
+func newMemoryBackend(broker *pubsub.OrderEventBroker) *storageBackend {
+	storage := memory.NewMemoryStorage()
+
+	return &storageBackend{
+		txManager:          storage,
+		user:               inmemory.NewUserRepository(storage),
+		order:              inmemory.NewOrderRepository(storage),
+		orderStatusHistory: inmemory.NewOrderStatusHistoryRepository(storage),
+		withdraw:           inmemory.NewWithdrawnRepository(storage),
+		withdrawHold:       inmemory.NewWithdrawHoldRepository(storage),
+		balanceAdjustment:  inmemory.NewBalanceAdjustmentRepository(storage),
+		transfer:           inmemory.NewTransferRepository(storage),
+		statement:          inmemory.NewStatementRepository(storage),
+		expiration:         inmemory.NewExpirationRepository(storage),
+		userTier:           inmemory.NewUserTierRepository(storage),
+		outbox:             inmemory.NewOutboxRepository(storage),
+		webhook:            inmemory.NewWebhookRepository(storage),
+		webhookDelivery:    inmemory.NewWebhookDeliveryRepository(storage),
+		reconciliation:     inmemory.NewReconciliationRepository(storage),
+		rateLimit:          inmemory.NewRateLimitRepository(),
+		orderEvents:        pubsub.NewLocalPublisher(broker),
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	state            string
+	failures         int
+	openedAt         time.Time
+	trialInFlight    bool
+	now              func() time.Time
+	log              *zap.Logger
+}
+
+func NewCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration, log *zap.Logger) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		state:            CircuitClosed,
+		now:              time.Now,
+		log:              log,
+	}
+}
+
+func (b *CircuitBreaker) Allow() bool {
+	if b.failureThreshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.transition(CircuitHalfOpen)
+		b.trialInFlight = true
+		return true
+	case CircuitHalfOpen:
+		if b.trialInFlight {
+			return false
+		}
+		b.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trialInFlight = false
+	if b.state != CircuitClosed {
+		b.transition(CircuitClosed)
+	}
+}
+
+func (b *CircuitBreaker) Failure() {
+	if b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trialInFlight = false
+
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.failureThreshold) {
+		b.openedAt = b.now()
+		b.transition(CircuitOpen)
+	}
+}
+
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+}
+
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) transition(state string) {
+	fields := []zap.Field{
+		zap.String("circuit", b.name),
+		zap.String("from", b.state),
+		zap.String("to", state),
+		zap.Int("consecutive_failures", b.failures),
+	}
+
+	if state == CircuitClosed {
+		b.log.Info("Circuit breaker state changed", fields...)
+	} else {
+		b.log.Warn("Circuit breaker state changed", fields...)
+	}
+	b.state = state
+}
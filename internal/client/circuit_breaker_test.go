@@ -0,0 +1,66 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	newBreaker := func() *CircuitBreaker {
+		breaker := NewCircuitBreaker("accrual", 2, 30*time.Second, zap.NewNop())
+		breaker.now = func() time.Time { return now }
+		return breaker
+	}
+
+	t.Run("opens after consecutive failures only", func(t *testing.T) {
+		breaker := newBreaker()
+
+		breaker.Failure()
+		breaker.Success()
+		breaker.Failure()
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.True(t, breaker.Allow())
+
+		breaker.Failure()
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.False(t, breaker.Allow())
+	})
+
+	t.Run("half-opens after timeout and lets a single trial through", func(t *testing.T) {
+		start := now
+		defer func() { now = start }()
+
+		breaker := newBreaker()
+		breaker.Failure()
+		breaker.Failure()
+
+		now = now.Add(30 * time.Second)
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+		assert.True(t, breaker.Allow())
+		assert.False(t, breaker.Allow())
+
+		breaker.Failure()
+		assert.Equal(t, CircuitOpen, breaker.State())
+		assert.False(t, breaker.Allow())
+
+		now = now.Add(30 * time.Second)
+		assert.True(t, breaker.Allow())
+		breaker.Success()
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.True(t, breaker.Allow())
+	})
+
+	t.Run("never opens when disabled", func(t *testing.T) {
+		breaker := NewCircuitBreaker("accrual", 0, time.Second, zap.NewNop())
+		for i := 0; i < 10; i++ {
+			breaker.Failure()
+		}
+
+		assert.Equal(t, CircuitClosed, breaker.State())
+		assert.True(t, breaker.Allow())
+	})
+}
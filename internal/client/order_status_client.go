@@ -8,25 +8,54 @@ import (
 	"github.com/ruslanDantsov/gophermart/internal/dto/view"
 	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"net/http"
+	"regexp"
+	"strconv"
+	"time"
 )
 
 const GetOrderStatusURL = "%s/api/orders/%s"
 
+var rateLimitMessage = regexp.MustCompile(`No more than (\d+) requests per minute allowed`)
+
 type OrderStatusClient struct {
 	httpClient *resty.Client
 	baseURL    string
+	breaker    *CircuitBreaker
+	limiter    *RateLimiter
 }
 
-func NewOrderStatusClient(baseURL string) *OrderStatusClient {
-	httpClient := resty.New()
+func NewOrderStatusClient(baseURL string, timeout time.Duration, maxRetries int, retryWait time.Duration, retryMaxWait time.Duration, breaker *CircuitBreaker, limiter *RateLimiter) *OrderStatusClient {
+	httpClient := resty.New().
+		SetTimeout(timeout).
+		SetRetryCount(maxRetries).
+		SetRetryWaitTime(retryWait).
+		SetRetryMaxWaitTime(retryMaxWait).
+		AddRetryCondition(isRetryable).
+		OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			return limiter.Wait(req.Context())
+		})
 
 	return &OrderStatusClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
+		breaker:    breaker,
+		limiter:    limiter,
 	}
 }
 
+func isRetryable(resp *resty.Response, err error) bool {
+	return err != nil || resp.StatusCode() >= http.StatusInternalServerError
+}
+
+func (c *OrderStatusClient) CircuitState() string {
+	return c.breaker.State()
+}
+
 func (c *OrderStatusClient) GetAccrualData(ctx context.Context, orderID string) (*view.AccrualResponse, error) {
+	if !c.breaker.Allow() {
+		return nil, errs.New(errs.AccrualUnavailable, "accrual service circuit is open", nil)
+	}
+
 	url := fmt.Sprintf(GetOrderStatusURL, c.baseURL, orderID)
 
 	resp, err := c.httpClient.R().
@@ -35,9 +64,28 @@ func (c *OrderStatusClient) GetAccrualData(ctx context.Context, orderID string)
 		Get(url)
 
 	if err != nil {
+		if ctx.Err() != nil {
+			c.breaker.Release()
+		} else {
+			c.breaker.Failure()
+		}
 		return nil, err
 	}
 
+	switch {
+	case resp.StatusCode() >= http.StatusInternalServerError:
+		c.breaker.Failure()
+	case resp.StatusCode() == http.StatusTooManyRequests:
+		c.breaker.Release()
+		c.limiter.Learn(parseRateLimit(resp))
+	default:
+		c.breaker.Success()
+	}
+
+	if resp.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
 	if resp.StatusCode() != http.StatusOK {
 		errMessage := fmt.Sprintf("bad response from Accrual service %s: %v", orderID, resp.StatusCode())
 		return nil, errs.New(errs.OrderStatusClient, errMessage, nil)
@@ -51,3 +99,20 @@ func (c *OrderStatusClient) GetAccrualData(ctx context.Context, orderID string)
 
 	return &responseBody, err
 }
+
+func parseRateLimit(resp *resty.Response) (int, time.Duration) {
+	requestsPerMinute := 0
+	if match := rateLimitMessage.FindSubmatch(resp.Body()); match != nil {
+		requestsPerMinute, _ = strconv.Atoi(string(match[1]))
+	}
+
+	var retryAfter time.Duration
+	header := resp.Header().Get("Retry-After")
+	if seconds, err := strconv.Atoi(header); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		retryAfter = time.Until(date)
+	}
+
+	return requestsPerMinute, retryAfter
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/accrualsim"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOrderStatusClient_GetAccrualData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	simulator, err := accrualsim.NewSimulator(&config.AccrualSimConfig{
+		AutoRegister:      true,
+		DefaultAccrual:    500,
+		RequestsPerMinute: 2,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(simulator.Router())
+	defer server.Close()
+
+	limiter := NewRateLimiter("accrual", 0, zap.NewNop())
+	client := NewOrderStatusClient(server.URL, time.Second, 0, time.Millisecond, time.Millisecond, NewCircuitBreaker("accrual", 0, time.Second, zap.NewNop()), limiter)
+
+	t.Run("returns accrual of processed order", func(t *testing.T) {
+		response, err := client.GetAccrualData(context.Background(), "12345678903")
+		require.NoError(t, err)
+
+		assert.Equal(t, "12345678903", response.Order)
+		assert.Equal(t, view.AccrualOrderProcessedStatus, response.Status)
+		assert.Equal(t, float64(500), response.Accrual)
+	})
+
+	t.Run("returns no data for unregistered order", func(t *testing.T) {
+		response, err := client.GetAccrualData(context.Background(), "12345")
+		require.NoError(t, err)
+
+		assert.Nil(t, response)
+	})
+
+	t.Run("fails when rate limited", func(t *testing.T) {
+		_, err := client.GetAccrualData(context.Background(), "12345678903")
+
+		var appErr *errs.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, errs.OrderStatusClient, appErr.Code)
+		assert.Equal(t, 2, limiter.RequestsPerMinute())
+	})
+
+	t.Run("waits for rate limit before next request", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetAccrualData(ctx, "12345678903")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestOrderStatusClient_Retries(t *testing.T) {
+	newClient := func(url string, breaker *CircuitBreaker) *OrderStatusClient {
+		return NewOrderStatusClient(url, time.Second, 2, time.Millisecond, 5*time.Millisecond, breaker, NewRateLimiter("accrual", 0, zap.NewNop()))
+	}
+
+	t.Run("retries server errors until success", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"order":"12345678903","status":"PROCESSED","accrual":10}`))
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker("accrual", 3, time.Minute, zap.NewNop())
+		response, err := newClient(server.URL, breaker).GetAccrualData(context.Background(), "12345678903")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Equal(t, float64(10), response.Accrual)
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker("accrual", 1, time.Minute, zap.NewNop())
+		_, err := newClient(server.URL, breaker).GetAccrualData(context.Background(), "12345678903")
+		require.Error(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+
+	t.Run("opens circuit and stops calling a failing service", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker("accrual", 2, time.Minute, zap.NewNop())
+		client := newClient(server.URL, breaker)
+
+		for i := 0; i < 2; i++ {
+			_, err := client.GetAccrualData(context.Background(), "12345678903")
+			require.Error(t, err)
+		}
+		assert.Equal(t, int32(6), calls.Load())
+		assert.Equal(t, CircuitOpen, client.CircuitState())
+
+		_, err := client.GetAccrualData(context.Background(), "12345678903")
+
+		var appErr *errs.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, errs.AccrualUnavailable, appErr.Code)
+		assert.Equal(t, int32(6), calls.Load())
+	})
+	t.Run("keeps half-open circuit on rate limit", func(t *testing.T) {
+		var status atomic.Int32
+		status.Store(http.StatusInternalServerError)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(int(status.Load()))
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker("accrual", 1, time.Minute, zap.NewNop())
+		client := newClient(server.URL, breaker)
+
+		_, err := client.GetAccrualData(context.Background(), "12345678903")
+		require.Error(t, err)
+		require.Equal(t, CircuitOpen, breaker.State())
+
+		openedAt := time.Now()
+		breaker.now = func() time.Time { return openedAt.Add(2 * time.Minute) }
+
+		status.Store(http.StatusTooManyRequests)
+		_, err = client.GetAccrualData(context.Background(), "12345678903")
+		require.Error(t, err)
+		assert.Equal(t, CircuitHalfOpen, breaker.State(), "429 says nothing about the health of the service")
+
+		status.Store(http.StatusNotFound)
+		_, err = client.GetAccrualData(context.Background(), "12345678903")
+		var appErr *errs.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, errs.OrderStatusClient, appErr.Code, "the trial slot is released after 429")
+		assert.Equal(t, CircuitClosed, breaker.State())
+	})
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+const rateLimiterBurst = 1
+
+type RateLimiter struct {
+	mu           sync.Mutex
+	name         string
+	interval     time.Duration
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	now          func() time.Time
+	log          *zap.Logger
+}
+
+func NewRateLimiter(name string, requestsPerMinute int, log *zap.Logger) *RateLimiter {
+	limiter := &RateLimiter{
+		name:   name,
+		tokens: rateLimiterBurst,
+		now:    time.Now,
+		log:    log,
+	}
+	if requestsPerMinute > 0 {
+		limiter.interval = time.Minute / time.Duration(requestsPerMinute)
+	}
+	return limiter
+}
+
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	start := now
+	if l.blockedUntil.After(start) {
+		start = l.blockedUntil
+	}
+
+	if l.interval <= 0 {
+		return start.Sub(now)
+	}
+
+	if elapsed := start.Sub(l.last); elapsed > 0 {
+		l.tokens = min(rateLimiterBurst, l.tokens+float64(elapsed)/float64(l.interval))
+		l.last = start
+	}
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return start.Sub(now)
+	}
+	return start.Sub(now) + time.Duration(-l.tokens*float64(l.interval))
+}
+
+func (l *RateLimiter) Learn(requestsPerMinute int, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if requestsPerMinute > 0 {
+		interval := time.Minute / time.Duration(requestsPerMinute)
+		if interval != l.interval {
+			l.interval = interval
+			l.log.Info("Rate limit learned",
+				zap.String("limiter", l.name),
+				zap.Int("requests_per_minute", requestsPerMinute),
+			)
+		}
+	}
+
+	if retryAfter > 0 {
+		blockedUntil := l.now().Add(retryAfter)
+		if blockedUntil.After(l.blockedUntil) {
+			l.blockedUntil = blockedUntil
+			l.tokens = rateLimiterBurst
+			l.last = blockedUntil
+			l.log.Warn("Requests are paused by rate limit",
+				zap.String("limiter", l.name),
+				zap.Duration("retry_after", retryAfter),
+			)
+		}
+	}
+}
+
+func (l *RateLimiter) RequestsPerMinute() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.interval <= 0 {
+		return 0
+	}
+	return int(time.Minute / l.interval)
+}
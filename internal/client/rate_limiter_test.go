@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRateLimiter(t *testing.T) {
+	newLimiter := func(requestsPerMinute int, now *time.Time) *RateLimiter {
+		limiter := NewRateLimiter("accrual", requestsPerMinute, zap.NewNop())
+		limiter.now = func() time.Time { return *now }
+		return limiter
+	}
+
+	t.Run("does not limit until rate is known", func(t *testing.T) {
+		now := time.Now()
+		limiter := newLimiter(0, &now)
+
+		for i := 0; i < 100; i++ {
+			assert.Zero(t, limiter.reserve())
+		}
+		assert.NoError(t, limiter.Wait(context.Background()))
+	})
+
+	t.Run("spreads requests evenly over the minute", func(t *testing.T) {
+		now := time.Now()
+		limiter := newLimiter(6, &now)
+
+		assert.Zero(t, limiter.reserve())
+		assert.Equal(t, 10*time.Second, limiter.reserve())
+		assert.Equal(t, 20*time.Second, limiter.reserve())
+
+		now = now.Add(30 * time.Second)
+		assert.Zero(t, limiter.reserve())
+		assert.Equal(t, 10*time.Second, limiter.reserve())
+	})
+
+	t.Run("learns rate and pauses until retry after", func(t *testing.T) {
+		now := time.Now()
+		limiter := newLimiter(0, &now)
+
+		limiter.Learn(30, 15*time.Second)
+		assert.Equal(t, 30, limiter.RequestsPerMinute())
+
+		assert.Equal(t, 15*time.Second, limiter.reserve())
+		assert.Equal(t, 17*time.Second, limiter.reserve())
+	})
+
+	t.Run("keeps rate when response does not announce it", func(t *testing.T) {
+		now := time.Now()
+		limiter := newLimiter(60, &now)
+
+		limiter.Learn(0, 0)
+		assert.Equal(t, 60, limiter.RequestsPerMinute())
+		assert.Zero(t, limiter.reserve())
+	})
+
+	t.Run("gives up waiting when context is done", func(t *testing.T) {
+		now := time.Now()
+		limiter := newLimiter(0, &now)
+		limiter.Learn(0, time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+	})
+}
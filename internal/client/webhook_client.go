@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/netguard"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	WebhookEventHeader     = "X-Gophermart-Event"
+	WebhookDeliveryHeader  = "X-Gophermart-Delivery"
+	WebhookTimestampHeader = "X-Gophermart-Timestamp"
+	WebhookSignatureHeader = "X-Gophermart-Signature"
+)
+
+type WebhookClient struct {
+	httpClient *resty.Client
+}
+
+func NewWebhookClient(timeout time.Duration) *WebhookClient {
+	return newWebhookClient(timeout, netguard.Control)
+}
+
+func newWebhookClient(timeout time.Duration, control func(network string, address string, c syscall.RawConn) error) *WebhookClient {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+	httpClient := resty.New().
+		SetTimeout(timeout).
+		SetTransport(&http.Transport{DialContext: dialer.DialContext})
+
+	return &WebhookClient{
+		httpClient: httpClient,
+	}
+}
+
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *WebhookClient) Send(ctx context.Context, task business.WebhookDeliveryTask) error {
+	body, err := easyjson.Marshal(view.WebhookEventViewModel{
+		ID:        task.EventID,
+		Type:      task.EventType,
+		CreatedAt: task.EventCreatedAt,
+		Data:      task.Payload,
+	})
+	if err != nil {
+		return errs.New(errs.Generic, "failed to marshal webhook payload", err)
+	}
+
+	timestamp := time.Now().Unix()
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(WebhookEventHeader, task.EventType).
+		SetHeader(WebhookDeliveryHeader, task.DeliveryID.String()).
+		SetHeader(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10)).
+		SetHeader(WebhookSignatureHeader, SignWebhookPayload(task.Secret, timestamp, body)).
+		SetBody(body).
+		Post(task.URL)
+
+	if err != nil {
+		return errs.New(errs.WebhookDelivery, "failed to send webhook", err)
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		return errs.New(errs.WebhookDelivery, "unexpected webhook response status",
+			fmt.Errorf("receiver %s answered with status %d", task.URL, resp.StatusCode()))
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookClient_Send(t *testing.T) {
+	task := business.WebhookDeliveryTask{
+		DeliveryID:     uuid.New(),
+		Secret:         "webhook-secret",
+		EventID:        uuid.New(),
+		EventType:      "order.processed",
+		Payload:        []byte(`{"number":"12345678903","status":"PROCESSED","accrual":500}`),
+		EventCreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("delivers signed payload", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		task := task
+		task.URL = receiver.URL
+
+		err := newWebhookClient(time.Second, nil).Send(context.Background(), task)
+		require.NoError(t, err)
+		require.NotNil(t, received)
+
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, "order.processed", received.Header.Get(WebhookEventHeader))
+		assert.Equal(t, task.DeliveryID.String(), received.Header.Get(WebhookDeliveryHeader))
+
+		mac := hmac.New(sha256.New, []byte(task.Secret))
+		mac.Write([]byte(received.Header.Get(WebhookTimestampHeader) + "."))
+		mac.Write(receivedBody)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get(WebhookSignatureHeader))
+
+		var event view.WebhookEventViewModel
+		require.NoError(t, easyjson.Unmarshal(receivedBody, &event))
+		assert.Equal(t, task.EventID, event.ID)
+		assert.Equal(t, task.EventType, event.Type)
+		assert.JSONEq(t, string(task.Payload), string(event.Data))
+	})
+
+	t.Run("fails on non-2xx response", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		task := task
+		task.URL = receiver.URL
+
+		err := newWebhookClient(time.Second, nil).Send(context.Background(), task)
+		assert.Error(t, err)
+	})
+	t.Run("refuses non-public receiver", func(t *testing.T) {
+		called := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		task := task
+		task.URL = receiver.URL
+
+		err := NewWebhookClient(time.Second).Send(context.Background(), task)
+		assert.ErrorContains(t, err, "non-public address 127.0.0.1")
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/jessevdk/go-flags"
+	"time"
+)
+
+type AccrualSimConfig struct {
+	Address                   string        `short:"a" long:"address" env:"RUN_ADDRESS" default:"localhost:8080" description:"Simulator host address"`
+	LogLevel                  string        `short:"l" long:"log" env:"LOG_LEVEL" default:"INFO" description:"Log Level"`
+	RegisteredDelayInSeconds  int           `long:"registered-delay" env:"ACCRUAL_SIM_REGISTERED_DELAY" default:"1" description:"Time (in seconds) an order stays REGISTERED"`
+	RegisteredDelay           time.Duration `description:"Derived duration from RegisteredDelayInSeconds"`
+	ProcessingDelayInSeconds  int           `long:"processing-delay" env:"ACCRUAL_SIM_PROCESSING_DELAY" default:"2" description:"Time (in seconds) an order stays PROCESSING before reaching PROCESSED or INVALID"`
+	ProcessingDelay           time.Duration `description:"Derived duration from ProcessingDelayInSeconds"`
+	RewardRules               []string      `long:"reward" env:"ACCRUAL_SIM_REWARDS" env-delim:"," description:"Reward rule in the form match:reward:type where type is % or pt, can be repeated"`
+	AutoRegister              bool          `long:"auto-register" env:"ACCRUAL_SIM_AUTO_REGISTER" description:"Register unknown orders on first request instead of answering 204"`
+	DefaultAccrual            float64       `long:"default-accrual" env:"ACCRUAL_SIM_DEFAULT_ACCRUAL" default:"100" description:"Accrual of automatically registered orders"`
+	RequestsPerMinute         int           `long:"rpm" env:"ACCRUAL_SIM_RPM" default:"0" description:"Maximum order status requests per minute before answering 429, 0 disables the limit"`
+	GracefulShutdownInSeconds int           `short:"s" long:"shutdown" env:"SHUTDOWN_INTERVAL" default:"5" description:"Frequency (in seconds) for graceful shutdown"`
+	GracefulShutdownInterval  time.Duration `description:"Derived duration from GracefulShutdownInSeconds"`
+}
+
+func NewAccrualSimConfig(cliArgs []string) (*AccrualSimConfig, error) {
+	config := &AccrualSimConfig{}
+	parser := flags.NewParser(config, flags.Default)
+
+	_, err := parser.ParseArgs(cliArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	config.RegisteredDelay = time.Duration(config.RegisteredDelayInSeconds) * time.Second
+	config.ProcessingDelay = time.Duration(config.ProcessingDelayInSeconds) * time.Second
+	config.GracefulShutdownInterval = time.Duration(config.GracefulShutdownInSeconds) * time.Second
+	return config, nil
+}
@@ -1,32 +1,200 @@
 package config
 
 import (
+	"fmt"
 	"github.com/jessevdk/go-flags"
+	"os"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Address                   string        `short:"a" long:"address" env:"RUN_ADDRESS" default:"localhost:8090" description:"Server host address"`
-	LogLevel                  string        `short:"l" long:"log" env:"LOG_LEVEL" default:"INFO" description:"Log Level"`
-	DatabaseConnection        string        `short:"d" long:"database" env:"DATABASE_URI" description:"Database connection string"`
-	JWTSecret                 string        `short:"j" long:"jwt" env:"JWT_SECRET" default:"rabbit_Hole" description:"Database connection string"`
-	ReportIntervalInSeconds   int           `short:"i" long:"interval" env:"REPORT_INTERVAL" default:"10" description:"Frequency (in seconds) for sending requests to the accrual server"`
-	ReportInterval            time.Duration `long:"-" description:"Derived duration from ReportIntervalInSeconds"`
-	AccrualSystemAddress      string        `short:"r" long:"accrual" env:"ACCRUAL_SYSTEM_ADDRESS" default:"http://localhost:8080" description:"Accrual system host address"`
-	GracefulShutdownInSeconds int           `short:"s" long:"shutdown" env:"SHUTDOWN_INTERVAL" default:"30" description:"Frequency (in seconds) for graceful shutdown"`
-	GracefulShutdownInterval  time.Duration `description:"Derived duration from GracefulShutdownInSeconds"`
+	ConfigFile                        string        `short:"c" long:"config" env:"CONFIG_FILE" description:"YAML or TOML file with options keyed by their long names, flags and environment variables take precedence over it"`
+	Environment                       string        `long:"environment" env:"APP_ENV" default:"development" choice:"development" choice:"production" description:"Environment the server runs in, production rejects insecure defaults"`
+	Address                           string        `short:"a" long:"address" env:"RUN_ADDRESS" default:"localhost:8090" description:"Server host address"`
+	TLSCertFile                       string        `long:"tls-cert" env:"TLS_CERT_FILE" description:"Certificate file (PEM) for serving HTTPS with HTTP/2, reloaded when it changes"`
+	TLSKeyFile                        string        `long:"tls-key" env:"TLS_KEY_FILE" description:"Private key file (PEM) of the TLS certificate"`
+	TLSClientCAFile                   string        `long:"tls-client-ca" env:"TLS_CLIENT_CA_FILE" description:"CA certificates file (PEM) for verifying client certificates, admin and internal routes require one when set"`
+	TLSReloadIntervalInSeconds        int           `long:"tls-reload-interval" env:"TLS_RELOAD_INTERVAL" default:"30" description:"Frequency (in seconds) for checking the TLS certificate files for changes"`
+	TLSReloadInterval                 time.Duration `description:"Derived duration from TLSReloadIntervalInSeconds"`
+	H2C                               bool          `long:"h2c" env:"H2C" description:"Serve HTTP/2 without TLS"`
+	ReadHeaderTimeoutInSeconds        int           `long:"read-header-timeout" env:"READ_HEADER_TIMEOUT" default:"5" description:"Time (in seconds) allowed for reading request headers"`
+	ReadHeaderTimeout                 time.Duration `description:"Derived duration from ReadHeaderTimeoutInSeconds"`
+	ReadTimeoutInSeconds              int           `long:"read-timeout" env:"READ_TIMEOUT" default:"30" description:"Time (in seconds) allowed for reading a whole request"`
+	ReadTimeout                       time.Duration `description:"Derived duration from ReadTimeoutInSeconds"`
+	WriteTimeoutInSeconds             int           `long:"write-timeout" env:"WRITE_TIMEOUT" default:"30" description:"Time (in seconds) allowed for writing a response, event streams are not limited"`
+	WriteTimeout                      time.Duration `description:"Derived duration from WriteTimeoutInSeconds"`
+	IdleTimeoutInSeconds              int           `long:"idle-timeout" env:"IDLE_TIMEOUT" default:"120" description:"Time (in seconds) an idle keep-alive connection is kept open"`
+	IdleTimeout                       time.Duration `description:"Derived duration from IdleTimeoutInSeconds"`
+	MaxHeaderBytes                    int           `long:"max-header-bytes" env:"MAX_HEADER_BYTES" default:"1048576" description:"Maximum size of request headers in bytes"`
+	LogLevel                          string        `short:"l" long:"log" reload:"true" env:"LOG_LEVEL" default:"INFO" description:"Log Level"`
+	DatabaseConnection                string        `short:"d" long:"database" env:"DATABASE_URI" redact:"password" description:"Database connection string, data is kept in memory when empty"`
+	DatabaseConnectionFile            string        `long:"database-file" env:"DATABASE_URI_FILE" description:"File with the database connection string, replaces --database"`
+	JWTSecret                         string        `short:"j" long:"jwt" reload:"true" env:"JWT_SECRET" default:"rabbit_Hole" redact:"all" description:"Secret for signing authentication tokens"`
+	JWTSecretFile                     string        `long:"jwt-file" reload:"true" env:"JWT_SECRET_FILE" description:"File with the secret for signing authentication tokens, replaces --jwt"`
+	ReportIntervalInSeconds           int           `short:"i" long:"interval" reload:"true" env:"REPORT_INTERVAL" default:"10" description:"Frequency (in seconds) for sending requests to the accrual server"`
+	ReportInterval                    time.Duration `description:"Derived duration from ReportIntervalInSeconds"`
+	SkipMigrations                    bool          `long:"skip-migrations" env:"SKIP_MIGRATIONS" description:"Do not apply database migrations on server start, run the migrate subcommand instead"`
+	AccrualSystemAddress              string        `short:"r" long:"accrual" env:"ACCRUAL_SYSTEM_ADDRESS" default:"http://localhost:8080" description:"Accrual system host address"`
+	AccrualProviders                  []string      `long:"accrual-provider" env:"ACCRUAL_PROVIDERS" env-delim:";" description:"Additional accrual system as name|address|rule, rule is prefix=P, regex=R or length=N; orders matching no rule are sent to the accrual system address"`
+	AccrualTimeoutInSeconds           int           `long:"accrual-timeout" env:"ACCRUAL_TIMEOUT" default:"5" description:"Timeout (in seconds) of a single request to the accrual system"`
+	AccrualTimeout                    time.Duration `description:"Derived duration from AccrualTimeoutInSeconds"`
+	AccrualMaxRetries                 int           `long:"accrual-max-retries" env:"ACCRUAL_MAX_RETRIES" default:"2" description:"Number of retries of a request to the accrual system failed with a network error or 5xx"`
+	AccrualRetryWaitInSeconds         int           `long:"accrual-retry-wait" env:"ACCRUAL_RETRY_WAIT" default:"1" description:"Initial delay (in seconds) before retrying a request to the accrual system, grows with jitter on every retry"`
+	AccrualRetryWait                  time.Duration `description:"Derived duration from AccrualRetryWaitInSeconds"`
+	AccrualRetryMaxWaitInSeconds      int           `long:"accrual-retry-max-wait" env:"ACCRUAL_RETRY_MAX_WAIT" default:"5" description:"Upper bound (in seconds) for the delay between retries to the accrual system"`
+	AccrualRetryMaxWait               time.Duration `description:"Derived duration from AccrualRetryMaxWaitInSeconds"`
+	AccrualBreakerThreshold           int           `long:"accrual-breaker-threshold" env:"ACCRUAL_BREAKER_THRESHOLD" default:"5" description:"Consecutive failed requests that open the accrual circuit breaker, 0 disables the breaker"`
+	AccrualBreakerOpenInSeconds       int           `long:"accrual-breaker-open" env:"ACCRUAL_BREAKER_OPEN" default:"30" description:"Time (in seconds) the accrual circuit breaker stays open before a trial request is let through"`
+	AccrualBreakerOpenTimeout         time.Duration `description:"Derived duration from AccrualBreakerOpenInSeconds"`
+	AccrualRequestsPerMinute          int           `long:"accrual-rpm" env:"ACCRUAL_REQUESTS_PER_MINUTE" default:"0" description:"Requests per minute allowed to each accrual system, 0 leaves requests unlimited until the limit is learned from a 429 response"`
+	AccrualCallbackSecret             string        `long:"accrual-callback-secret" env:"ACCRUAL_CALLBACK_SECRET" redact:"all" description:"Secret for verifying signatures of accrual callbacks, the callback endpoint is disabled when empty"`
+	AccrualCallbackSecretFile         string        `long:"accrual-callback-secret-file" env:"ACCRUAL_CALLBACK_SECRET_FILE" description:"File with the secret for verifying signatures of accrual callbacks, replaces --accrual-callback-secret"`
+	AccrualCallbackToleranceInSeconds int           `long:"accrual-callback-tolerance" env:"ACCRUAL_CALLBACK_TOLERANCE" default:"300" description:"Maximum age (in seconds) of a signed accrual callback"`
+	AccrualCallbackTolerance          time.Duration `description:"Derived duration from AccrualCallbackToleranceInSeconds"`
+	AccrualFallbackIntervalInSeconds  int           `long:"accrual-fallback-interval" reload:"true" env:"ACCRUAL_FALLBACK_INTERVAL" default:"300" description:"Frequency (in seconds) for polling the accrual system when callbacks are enabled"`
+	AccrualFallbackInterval           time.Duration `description:"Derived duration from AccrualFallbackIntervalInSeconds"`
+	AccrualWorkers                    int           `long:"accrual-workers" reload:"true" env:"ACCRUAL_WORKERS" default:"1" description:"Number of orders polled from the accrual system concurrently"`
+	GracefulShutdownInSeconds         int           `short:"s" long:"shutdown" env:"SHUTDOWN_INTERVAL" default:"30" description:"Frequency (in seconds) for graceful shutdown"`
+	GracefulShutdownInterval          time.Duration `description:"Derived duration from GracefulShutdownInSeconds"`
+	AdminLogins                       []string      `long:"admin-login" env:"ADMIN_LOGINS" env-delim:"," description:"Logins of existing users that are granted the ADMIN role on startup"`
+	WebhookDispatchInSeconds          int           `long:"webhook-interval" env:"WEBHOOK_DISPATCH_INTERVAL" default:"5" description:"Frequency (in seconds) for dispatching webhook deliveries"`
+	WebhookDispatchInterval           time.Duration `description:"Derived duration from WebhookDispatchInSeconds"`
+	WebhookTimeoutInSeconds           int           `long:"webhook-timeout" env:"WEBHOOK_TIMEOUT" default:"10" description:"Timeout (in seconds) of a single webhook delivery request"`
+	WebhookTimeout                    time.Duration `description:"Derived duration from WebhookTimeoutInSeconds"`
+	WebhookMaxAttempts                int           `long:"webhook-max-attempts" env:"WEBHOOK_MAX_ATTEMPTS" default:"8" description:"Number of delivery attempts before a webhook delivery is marked as failed"`
+	WebhookBackoffInSeconds           int           `long:"webhook-backoff" env:"WEBHOOK_BACKOFF" default:"10" description:"Initial delay (in seconds) before retrying a failed webhook delivery, doubled on every attempt"`
+	WebhookBackoff                    time.Duration `description:"Derived duration from WebhookBackoffInSeconds"`
+	WebhookMaxBackoffInSeconds        int           `long:"webhook-max-backoff" env:"WEBHOOK_MAX_BACKOFF" default:"3600" description:"Upper bound (in seconds) for the webhook retry delay"`
+	WebhookMaxBackoff                 time.Duration `description:"Derived duration from WebhookMaxBackoffInSeconds"`
+	PointsExpirationMonths            int           `long:"points-expiration-months" env:"POINTS_EXPIRATION_MONTHS" default:"0" description:"Number of months after accrual when unspent points expire, 0 disables expiration"`
+	PointsExpirationInSeconds         int           `long:"points-expiration-interval" env:"POINTS_EXPIRATION_INTERVAL" default:"3600" description:"Frequency (in seconds) for running the points expiration job"`
+	PointsExpirationInterval          time.Duration `description:"Derived duration from PointsExpirationInSeconds"`
+	PointsExpiringSoonInDays          int           `long:"points-expiring-soon" env:"POINTS_EXPIRING_SOON_DAYS" default:"30" description:"Window (in days) for reporting points as expiring soon in the balance"`
+	PointsExpiringSoonWindow          time.Duration `description:"Derived duration from PointsExpiringSoonInDays"`
+	WithdrawHoldTTLInSeconds          int           `long:"hold-ttl" env:"WITHDRAW_HOLD_TTL" default:"900" description:"Time (in seconds) after which an uncaptured withdraw hold is released"`
+	WithdrawHoldTTL                   time.Duration `description:"Derived duration from WithdrawHoldTTLInSeconds"`
+	WithdrawHoldSweepInSeconds        int           `long:"hold-sweep-interval" env:"WITHDRAW_HOLD_SWEEP_INTERVAL" default:"30" description:"Frequency (in seconds) for releasing expired withdraw holds"`
+	WithdrawHoldSweepInterval         time.Duration `description:"Derived duration from WithdrawHoldSweepInSeconds"`
+	TierWindowInDays                  int           `long:"tier-window" env:"TIER_WINDOW_DAYS" default:"365" description:"Rolling window (in days) of processed accruals counted for the loyalty tier, 0 counts all accruals"`
+	TierWindow                        time.Duration `description:"Derived duration from TierWindowInDays"`
+	TierSweepInSeconds                int           `long:"tier-sweep-interval" env:"TIER_SWEEP_INTERVAL" default:"3600" description:"Frequency (in seconds) for downgrading tiers whose accruals left the tier window"`
+	TierSweepInterval                 time.Duration `description:"Derived duration from TierSweepInSeconds"`
+	TierBronzeThreshold               float64       `long:"tier-bronze" env:"TIER_BRONZE_THRESHOLD" default:"0" description:"Accruals within the tier window required for the BRONZE tier"`
+	TierSilverThreshold               float64       `long:"tier-silver" env:"TIER_SILVER_THRESHOLD" default:"1000" description:"Accruals within the tier window required for the SILVER tier"`
+	TierGoldThreshold                 float64       `long:"tier-gold" env:"TIER_GOLD_THRESHOLD" default:"5000" description:"Accruals within the tier window required for the GOLD tier"`
+	ReconcileIntervalInSeconds        int           `long:"reconcile-interval" env:"RECONCILE_INTERVAL" default:"86400" description:"Frequency (in seconds) for reconciling finalized orders with the accrual system, 0 disables the job"`
+	ReconcileInterval                 time.Duration `description:"Derived duration from ReconcileIntervalInSeconds"`
+	ReconcileSampleSize               int           `long:"reconcile-sample" env:"RECONCILE_SAMPLE" default:"100" description:"Number of random finalized orders checked per reconciliation run, 0 checks all of them"`
+	ReconcileApplyCorrections         bool          `long:"reconcile-apply" env:"RECONCILE_APPLY" description:"Credit or debit differences found by reconciliation as balance adjustments"`
+	ReconcileReportDir                string        `long:"reconcile-report-dir" env:"RECONCILE_REPORT_DIR" description:"Directory for JSON reports of reconciliation runs, mismatches are only stored in the database when empty"`
+	TransferDailyLimit                float64       `long:"transfer-daily-limit" env:"TRANSFER_DAILY_LIMIT" default:"1000" description:"Maximum sum of points a user can transfer to other users per day, 0 disables the limit"`
+	RateLimits                        []string      `long:"rate-limit" env:"RATE_LIMITS" env-delim:";" description:"Requests a client may send to a route as METHOD|path|requests/window, counted per user on authenticated routes and per IP on public ones. No route is limited unless set, suggested values: POST|/api/user/register|10/1m;POST|/api/user/login|20/1m;POST|/api/user/orders|60/1m;POST|/api/user/balance/withdraw|30/1m"`
+	DisableRateLimits                 bool          `long:"disable-rate-limits" env:"DISABLE_RATE_LIMITS" description:"Do not limit requests to any route"`
+	RateLimitStore                    string        `long:"rate-limit-store" env:"RATE_LIMIT_STORE" default:"local" choice:"local" choice:"postgres" description:"Where request counters are kept, postgres shares them between replicas"`
+	TrustedProxies                    []string      `long:"trusted-proxy" env:"TRUSTED_PROXIES" env-delim:"," description:"Proxy addresses or CIDRs whose X-Forwarded-For header is trusted for the client IP"`
 }
 
+const DefaultJWTSecret = "rabbit_Hole"
+
 func NewConfig(cliArgs []string) (*Config, error) {
+	config, err := Load(cliArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func Load(cliArgs []string) (*Config, error) {
 	config := &Config{}
-	parser := flags.NewParser(config, flags.Default)
+	if _, err := newParser(config).ParseArgs(cliArgs); err != nil {
+		return nil, err
+	}
 
-	_, err := parser.ParseArgs(cliArgs)
-	if err != nil {
-		panic(err)
+	if config.ConfigFile != "" {
+		values, err := readConfigFile(config.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+
+		configFile := config.ConfigFile
+		config = &Config{}
+		parser := newParser(config)
+		if err := applyConfigFile(parser, configFile, values); err != nil {
+			return nil, err
+		}
+		if _, err := parser.ParseArgs(cliArgs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.readSecretFiles(); err != nil {
+		return nil, err
 	}
 
-	config.ReportInterval = time.Duration(config.ReportIntervalInSeconds) * time.Second
-	config.GracefulShutdownInterval = time.Duration(config.GracefulShutdownInSeconds) * time.Second
+	config.derive()
 	return config, nil
 }
+
+func IsHelp(err error) bool {
+	return flags.WroteHelp(err)
+}
+
+func newParser(config *Config) *flags.Parser {
+	return flags.NewParser(config, flags.HelpFlag|flags.PassDoubleDash)
+}
+
+func (c *Config) readSecretFiles() error {
+	secrets := []struct {
+		file  string
+		value *string
+	}{
+		{file: c.DatabaseConnectionFile, value: &c.DatabaseConnection},
+		{file: c.JWTSecretFile, value: &c.JWTSecret},
+		{file: c.AccrualCallbackSecretFile, value: &c.AccrualCallbackSecret},
+	}
+
+	for _, secret := range secrets {
+		if secret.file == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(secret.file)
+		if err != nil {
+			return fmt.Errorf("read secret file: %w", err)
+		}
+		*secret.value = strings.TrimSpace(string(content))
+	}
+	return nil
+}
+
+func (c *Config) derive() {
+	c.TLSReloadInterval = time.Duration(c.TLSReloadIntervalInSeconds) * time.Second
+	c.ReadHeaderTimeout = time.Duration(c.ReadHeaderTimeoutInSeconds) * time.Second
+	c.ReadTimeout = time.Duration(c.ReadTimeoutInSeconds) * time.Second
+	c.WriteTimeout = time.Duration(c.WriteTimeoutInSeconds) * time.Second
+	c.IdleTimeout = time.Duration(c.IdleTimeoutInSeconds) * time.Second
+	c.ReportInterval = time.Duration(c.ReportIntervalInSeconds) * time.Second
+	c.AccrualTimeout = time.Duration(c.AccrualTimeoutInSeconds) * time.Second
+	c.AccrualRetryWait = time.Duration(c.AccrualRetryWaitInSeconds) * time.Second
+	c.AccrualRetryMaxWait = time.Duration(c.AccrualRetryMaxWaitInSeconds) * time.Second
+	c.AccrualBreakerOpenTimeout = time.Duration(c.AccrualBreakerOpenInSeconds) * time.Second
+	c.AccrualCallbackTolerance = time.Duration(c.AccrualCallbackToleranceInSeconds) * time.Second
+	c.AccrualFallbackInterval = time.Duration(c.AccrualFallbackIntervalInSeconds) * time.Second
+	c.ReconcileInterval = time.Duration(c.ReconcileIntervalInSeconds) * time.Second
+	c.GracefulShutdownInterval = time.Duration(c.GracefulShutdownInSeconds) * time.Second
+	c.WebhookDispatchInterval = time.Duration(c.WebhookDispatchInSeconds) * time.Second
+	c.WebhookTimeout = time.Duration(c.WebhookTimeoutInSeconds) * time.Second
+	c.WebhookBackoff = time.Duration(c.WebhookBackoffInSeconds) * time.Second
+	c.WebhookMaxBackoff = time.Duration(c.WebhookMaxBackoffInSeconds) * time.Second
+	c.PointsExpirationInterval = time.Duration(c.PointsExpirationInSeconds) * time.Second
+	c.PointsExpiringSoonWindow = time.Duration(c.PointsExpiringSoonInDays) * 24 * time.Hour
+	c.WithdrawHoldTTL = time.Duration(c.WithdrawHoldTTLInSeconds) * time.Second
+	c.TierWindow = time.Duration(c.TierWindowInDays) * 24 * time.Hour
+	c.TierSweepInterval = time.Duration(c.TierSweepInSeconds) * time.Second
+	c.WithdrawHoldSweepInterval = time.Duration(c.WithdrawHoldSweepInSeconds) * time.Second
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"github.com/jessevdk/go-flags"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func readConfigFile(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		return nil, fmt.Errorf("config file %s must have a .yaml, .yml or .toml extension", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func applyConfigFile(parser *flags.Parser, path string, values map[string]any) error {
+	for key, value := range values {
+		option := parser.FindOptionByLongName(key)
+		if option == nil || key == "config" {
+			return fmt.Errorf("unknown option %q in config file %s", key, path)
+		}
+
+		defaults, err := configFileValue(value)
+		if err != nil {
+			return fmt.Errorf("option %q in config file %s: %w", key, path, err)
+		}
+		option.Default = defaults
+	}
+	return nil
+}
+
+func configFileValue(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			scalar, err := configFileScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, scalar)
+		}
+		return values, nil
+	default:
+		scalar, err := configFileScalar(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{scalar}, nil
+	}
+}
+
+func configFileScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+	"net/url"
+	"reflect"
+	"regexp"
+)
+
+const redacted = "[REDACTED]"
+
+var dsnPasswordPattern = regexp.MustCompile(`password=\S+`)
+
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := field.Tag.Get("long")
+		if name == "" || name == "config" {
+			continue
+		}
+
+		fieldValue := value.Field(i).Interface()
+		if s, ok := fieldValue.(string); ok && s != "" {
+			fieldValue = redact(field.Tag.Get("redact"), s)
+		}
+
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(fieldValue); err != nil {
+			return err
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode)
+	}
+
+	if c.ConfigFile != "" {
+		root.HeadComment = "loaded from " + c.ConfigFile
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func redact(mode string, value string) string {
+	switch mode {
+	case "all":
+		return redacted
+	case "password":
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+		return dsnPasswordPattern.ReplaceAllString(value, "password="+redacted)
+	default:
+		return value
+	}
+}
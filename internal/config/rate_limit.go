@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func ParseRateLimit(spec string) (string, string, int, time.Duration, error) {
+	parts := strings.Split(spec, "|")
+	if len(parts) != 3 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+		return "", "", 0, 0, fmt.Errorf("invalid rate limit %q, expected METHOD|path|requests/window", spec)
+	}
+
+	requestsPart, windowPart, ok := strings.Cut(parts[2], "/")
+	requests, err := strconv.Atoi(requestsPart)
+	if !ok || err != nil || requests <= 0 {
+		return "", "", 0, 0, fmt.Errorf("invalid rate limit %q, requests must be a positive number", spec)
+	}
+
+	window, err := time.ParseDuration(windowPart)
+	if err != nil || window <= 0 {
+		return "", "", 0, 0, fmt.Errorf("invalid rate limit %q, window must be a positive duration like 1m", spec)
+	}
+
+	return strings.ToUpper(parts[0]), parts[1], requests, window, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"reflect"
+)
+
+func (c *Config) NonReloadableChanges(next *Config) []string {
+	var changed []string
+
+	current := reflect.ValueOf(c).Elem()
+	updated := reflect.ValueOf(next).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		name := field.Tag.Get("long")
+		if name == "" || field.Tag.Get("reload") == "true" {
+			continue
+		}
+
+		if !reflect.DeepEqual(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"net"
+	"net/url"
+	"strings"
+)
+
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	_, _, err := net.SplitHostPort(c.Address)
+	check(err == nil, "--address %q must be host:port", c.Address)
+
+	check((c.TLSCertFile == "") == (c.TLSKeyFile == ""), "--tls-cert and --tls-key must be set together")
+	check(c.TLSClientCAFile == "" || c.TLSCertFile != "", "--tls-client-ca requires --tls-cert and --tls-key")
+	check(c.TLSReloadIntervalInSeconds > 0, "--tls-reload-interval must be positive")
+	check(c.ReadHeaderTimeoutInSeconds > 0, "--read-header-timeout must be positive")
+	check(c.ReadTimeoutInSeconds > 0, "--read-timeout must be positive")
+	check(c.WriteTimeoutInSeconds > 0, "--write-timeout must be positive")
+	check(c.IdleTimeoutInSeconds > 0, "--idle-timeout must be positive")
+	check(c.MaxHeaderBytes > 0, "--max-header-bytes must be positive")
+
+	_, err = zapcore.ParseLevel(c.LogLevel)
+	check(err == nil, "--log %q is not a log level", c.LogLevel)
+
+	if strings.HasPrefix(c.DatabaseConnection, "postgres://") || strings.HasPrefix(c.DatabaseConnection, "postgresql://") {
+		_, err = url.Parse(c.DatabaseConnection)
+		check(err == nil, "--database is not a valid URL")
+	}
+
+	check(c.JWTSecret != "", "--jwt must not be empty")
+	check(c.Environment != "production" || c.JWTSecret != DefaultJWTSecret, "--jwt must not be the default secret in production")
+
+	check(isHTTPURL(c.AccrualSystemAddress), "--accrual %q must be an http or https URL", c.AccrualSystemAddress)
+	for _, provider := range c.AccrualProviders {
+		parts := strings.SplitN(provider, "|", 3)
+		check(len(parts) == 3 && parts[0] != "" && isHTTPURL(parts[1]), "--accrual-provider %q must be name|address|rule with an http or https address", provider)
+	}
+
+	check(c.ReportIntervalInSeconds > 0, "--interval must be positive")
+	check(c.AccrualTimeoutInSeconds > 0, "--accrual-timeout must be positive")
+	check(c.AccrualMaxRetries >= 0, "--accrual-max-retries must not be negative")
+	check(c.AccrualRetryWaitInSeconds >= 0, "--accrual-retry-wait must not be negative")
+	check(c.AccrualRetryMaxWaitInSeconds >= c.AccrualRetryWaitInSeconds, "--accrual-retry-max-wait must not be less than --accrual-retry-wait")
+	check(c.AccrualBreakerThreshold >= 0, "--accrual-breaker-threshold must not be negative")
+	check(c.AccrualBreakerOpenInSeconds > 0, "--accrual-breaker-open must be positive")
+	check(c.AccrualRequestsPerMinute >= 0, "--accrual-rpm must not be negative")
+	check(c.AccrualCallbackToleranceInSeconds > 0, "--accrual-callback-tolerance must be positive")
+	check(c.AccrualFallbackIntervalInSeconds > 0, "--accrual-fallback-interval must be positive")
+	check(c.AccrualWorkers > 0, "--accrual-workers must be positive")
+	check(c.GracefulShutdownInSeconds > 0, "--shutdown must be positive")
+	check(c.WebhookDispatchInSeconds > 0, "--webhook-interval must be positive")
+	check(c.WebhookTimeoutInSeconds > 0, "--webhook-timeout must be positive")
+	check(c.WebhookMaxAttempts > 0, "--webhook-max-attempts must be positive")
+	check(c.WebhookBackoffInSeconds > 0, "--webhook-backoff must be positive")
+	check(c.WebhookMaxBackoffInSeconds >= c.WebhookBackoffInSeconds, "--webhook-max-backoff must not be less than --webhook-backoff")
+	check(c.PointsExpirationMonths >= 0, "--points-expiration-months must not be negative")
+	check(c.PointsExpirationInSeconds > 0, "--points-expiration-interval must be positive")
+	check(c.PointsExpiringSoonInDays >= 0, "--points-expiring-soon must not be negative")
+	check(c.WithdrawHoldTTLInSeconds > 0, "--hold-ttl must be positive")
+	check(c.WithdrawHoldSweepInSeconds > 0, "--hold-sweep-interval must be positive")
+	check(c.TierWindowInDays >= 0, "--tier-window must not be negative")
+	check(c.TierSweepInSeconds > 0, "--tier-sweep-interval must be positive")
+	check(c.TierBronzeThreshold >= 0, "--tier-bronze must not be negative")
+	check(c.TierBronzeThreshold <= c.TierSilverThreshold && c.TierSilverThreshold <= c.TierGoldThreshold, "tier thresholds must grow from --tier-bronze to --tier-gold")
+	check(c.ReconcileIntervalInSeconds >= 0, "--reconcile-interval must not be negative")
+	check(c.ReconcileSampleSize >= 0, "--reconcile-sample must not be negative")
+	check(c.TransferDailyLimit >= 0, "--transfer-daily-limit must not be negative")
+	for _, limit := range c.RateLimits {
+		_, _, _, _, err := ParseRateLimit(limit)
+		check(err == nil, "--rate-limit: %v", err)
+	}
+	check(c.RateLimitStore != "postgres" || c.DatabaseConnection != "", "--rate-limit-store postgres requires --database")
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
+
+func isHTTPURL(address string) bool {
+	u, err := url.Parse(address)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
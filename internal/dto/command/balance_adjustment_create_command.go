@@ -0,0 +1,7 @@
+package command
+
+type BalanceAdjustmentCreateCommand struct {
+	Amount    float64 `json:"amount" binding:"required"`
+	Reason    string  `json:"reason" binding:"required"`
+	Reference string  `json:"reference"`
+}
@@ -0,0 +1,6 @@
+package command
+
+type TransferCreateCommand struct {
+	Recipient string  `json:"recipient" binding:"required"`
+	Sum       float64 `json:"sum" binding:"required,gt=0"`
+}
@@ -0,0 +1,5 @@
+package command
+
+type UserRoleUpdateCommand struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -0,0 +1,5 @@
+package command
+
+type WebhookCreateCommand struct {
+	URL string `json:"url" binding:"required"`
+}
@@ -0,0 +1,5 @@
+package command
+
+type WebhookUpdateCommand struct {
+	URL string `json:"url" binding:"required"`
+}
@@ -2,5 +2,5 @@ package command
 
 type WithdrawCreateCommand struct {
 	Order string  `json:"order" binding:"required"`
-	Sum   float64 `json:"sum" binding:"required"`
+	Sum   float64 `json:"sum" binding:"required,gt=0"`
 }
@@ -0,0 +1,12 @@
+package view
+
+//go:generate easyjson -all accrual_provider_view_model.go
+type AccrualProviderViewModel struct {
+	Name      string `json:"name"`
+	Rule      string `json:"rule,omitempty"`
+	Circuit   string `json:"circuit"`
+	Polled    int64  `json:"polled"`
+	Processed int64  `json:"processed"`
+	Failed    int64  `json:"failed"`
+	Postponed int64  `json:"postponed"`
+}
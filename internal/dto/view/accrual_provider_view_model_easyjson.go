@@ -0,0 +1,127 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson86e763ebDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *AccrualProviderViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "rule":
+			out.Rule = string(in.String())
+		case "circuit":
+			out.Circuit = string(in.String())
+		case "polled":
+			out.Polled = int64(in.Int64())
+		case "processed":
+			out.Processed = int64(in.Int64())
+		case "failed":
+			out.Failed = int64(in.Int64())
+		case "postponed":
+			out.Postponed = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson86e763ebEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in AccrualProviderViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Rule != "" {
+		const prefix string = ",\"rule\":"
+		out.RawString(prefix)
+		out.String(string(in.Rule))
+	}
+	{
+		const prefix string = ",\"circuit\":"
+		out.RawString(prefix)
+		out.String(string(in.Circuit))
+	}
+	{
+		const prefix string = ",\"polled\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Polled))
+	}
+	{
+		const prefix string = ",\"processed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Processed))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failed))
+	}
+	{
+		const prefix string = ",\"postponed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Postponed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccrualProviderViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson86e763ebEncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccrualProviderViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson86e763ebEncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccrualProviderViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson86e763ebDecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccrualProviderViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson86e763ebDecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
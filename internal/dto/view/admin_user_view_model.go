@@ -0,0 +1,14 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all admin_user_view_model.go
+type AdminUserViewModel struct {
+	ID        uuid.UUID `json:"id"`
+	Login     string    `json:"login"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,110 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3c15c353DecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *AdminUserViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "login":
+			out.Login = string(in.String())
+		case "role":
+			out.Role = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c15c353EncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in AdminUserViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix)
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdminUserViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c15c353EncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdminUserViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c15c353EncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdminUserViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c15c353DecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdminUserViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c15c353DecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
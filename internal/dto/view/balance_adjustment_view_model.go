@@ -0,0 +1,16 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all balance_adjustment_view_model.go
+type BalanceAdjustmentViewModel struct {
+	ID         uuid.UUID `json:"id"`
+	Amount     float64   `json:"amount"`
+	Reason     string    `json:"reason"`
+	Reference  string    `json:"reference,omitempty"`
+	OperatorID uuid.UUID `json:"operator_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,126 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonC9448f8eDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *BalanceAdjustmentViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "amount":
+			out.Amount = float64(in.Float64())
+		case "reason":
+			out.Reason = string(in.String())
+		case "reference":
+			out.Reference = string(in.String())
+		case "operator_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.OperatorID).UnmarshalText(data))
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC9448f8eEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in BalanceAdjustmentViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Amount))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.Reference != "" {
+		const prefix string = ",\"reference\":"
+		out.RawString(prefix)
+		out.String(string(in.Reference))
+	}
+	{
+		const prefix string = ",\"operator_id\":"
+		out.RawString(prefix)
+		out.RawText((in.OperatorID).MarshalText())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BalanceAdjustmentViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC9448f8eEncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BalanceAdjustmentViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC9448f8eEncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BalanceAdjustmentViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC9448f8eDecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BalanceAdjustmentViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC9448f8eDecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
@@ -2,6 +2,8 @@ package view
 
 //go:generate easyjson -all balance_view_model.go
 type BalanceViewModel struct {
-	Current   float64 `json:"current"`
-	Withdrawn float64 `json:"withdrawn"`
+	Current      float64 `json:"current"`
+	Withdrawn    float64 `json:"withdrawn"`
+	Held         float64 `json:"held"`
+	ExpiringSoon float64 `json:"expiring_soon"`
 }
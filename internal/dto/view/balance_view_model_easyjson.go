@@ -40,6 +40,10 @@ func easyjsonD4bf1ad6DecodeGithubComRuslanDantsovGophermartInternalDtoView(in *j
 			out.Current = float64(in.Float64())
 		case "withdrawn":
 			out.Withdrawn = float64(in.Float64())
+		case "held":
+			out.Held = float64(in.Float64())
+		case "expiring_soon":
+			out.ExpiringSoon = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -64,6 +68,16 @@ func easyjsonD4bf1ad6EncodeGithubComRuslanDantsovGophermartInternalDtoView(out *
 		out.RawString(prefix)
 		out.Float64(float64(in.Withdrawn))
 	}
+	{
+		const prefix string = ",\"held\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Held))
+	}
+	{
+		const prefix string = ",\"expiring_soon\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ExpiringSoon))
+	}
 	out.RawByte('}')
 }
 
@@ -0,0 +1,14 @@
+package view
+
+//go:generate easyjson -all health_view_model.go
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+)
+
+type HealthViewModel struct {
+	Status           string            `json:"status"`
+	AccrualCircuit   string            `json:"accrual_circuit"`
+	AccrualProviders map[string]string `json:"accrual_providers"`
+}
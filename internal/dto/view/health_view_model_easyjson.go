@@ -0,0 +1,129 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA02d236aDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *HealthViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "accrual_circuit":
+			out.AccrualCircuit = string(in.String())
+		case "accrual_providers":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.AccrualProviders = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 string
+					v1 = string(in.String())
+					(out.AccrualProviders)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA02d236aEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in HealthViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"accrual_circuit\":"
+		out.RawString(prefix)
+		out.String(string(in.AccrualCircuit))
+	}
+	{
+		const prefix string = ",\"accrual_providers\":"
+		out.RawString(prefix)
+		if in.AccrualProviders == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.AccrualProviders {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				out.String(string(v2Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HealthViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA02d236aEncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HealthViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA02d236aEncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HealthViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA02d236aDecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HealthViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA02d236aDecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
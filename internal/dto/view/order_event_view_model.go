@@ -0,0 +1,13 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all order_event_view_model.go
+type OrderEventViewModel struct {
+	Number    string    `json:"number"`
+	Status    string    `json:"status"`
+	Accrual   float64   `json:"accrual,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
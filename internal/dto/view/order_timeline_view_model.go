@@ -0,0 +1,22 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all order_timeline_view_model.go
+type OrderTimelineViewModel struct {
+	Number     string                       `json:"number"`
+	UserID     uuid.UUID                    `json:"user_id"`
+	Status     string                       `json:"status"`
+	Accrual    float64                      `json:"accrual"`
+	UploadedAt time.Time                    `json:"uploaded_at"`
+	History    []OrderStatusChangeViewModel `json:"history"`
+}
+
+type OrderStatusChangeViewModel struct {
+	Status    string    `json:"status"`
+	Accrual   float64   `json:"accrual"`
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -0,0 +1,238 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *OrderTimelineViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "number":
+			out.Number = string(in.String())
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "status":
+			out.Status = string(in.String())
+		case "accrual":
+			out.Accrual = float64(in.Float64())
+		case "uploaded_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.UploadedAt).UnmarshalJSON(data))
+			}
+		case "history":
+			if in.IsNull() {
+				in.Skip()
+				out.History = nil
+			} else {
+				in.Delim('[')
+				if out.History == nil {
+					if !in.IsDelim(']') {
+						out.History = make([]OrderStatusChangeViewModel, 0, 1)
+					} else {
+						out.History = []OrderStatusChangeViewModel{}
+					}
+				} else {
+					out.History = (out.History)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 OrderStatusChangeViewModel
+					(v1).UnmarshalEasyJSON(in)
+					out.History = append(out.History, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in OrderTimelineViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"number\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Number))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"accrual\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Accrual))
+	}
+	{
+		const prefix string = ",\"uploaded_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UploadedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"history\":"
+		out.RawString(prefix)
+		if in.History == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.History {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderTimelineViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderTimelineViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderTimelineViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderTimelineViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
+func easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView1(in *jlexer.Lexer, out *OrderStatusChangeViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "accrual":
+			out.Accrual = float64(in.Float64())
+		case "changed_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ChangedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView1(out *jwriter.Writer, in OrderStatusChangeViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"accrual\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Accrual))
+	}
+	{
+		const prefix string = ",\"changed_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ChangedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OrderStatusChangeViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OrderStatusChangeViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF606a7a2EncodeGithubComRuslanDantsovGophermartInternalDtoView1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OrderStatusChangeViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OrderStatusChangeViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF606a7a2DecodeGithubComRuslanDantsovGophermartInternalDtoView1(l, v)
+}
@@ -0,0 +1,15 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all profile_view_model.go
+type ProfileViewModel struct {
+	Login      string     `json:"login"`
+	Tier       string     `json:"tier"`
+	TierSince  *time.Time `json:"tier_since,omitempty"`
+	Accrued    float64    `json:"accrued"`
+	NextTier   string     `json:"next_tier,omitempty"`
+	ToNextTier float64    `json:"to_next_tier,omitempty"`
+}
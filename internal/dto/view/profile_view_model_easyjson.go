@@ -0,0 +1,131 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson1dc4c543DecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *ProfileViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "login":
+			out.Login = string(in.String())
+		case "tier":
+			out.Tier = string(in.String())
+		case "tier_since":
+			if in.IsNull() {
+				in.Skip()
+				out.TierSince = nil
+			} else {
+				if out.TierSince == nil {
+					out.TierSince = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.TierSince).UnmarshalJSON(data))
+				}
+			}
+		case "accrued":
+			out.Accrued = float64(in.Float64())
+		case "next_tier":
+			out.NextTier = string(in.String())
+		case "to_next_tier":
+			out.ToNextTier = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1dc4c543EncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in ProfileViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"login\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Login))
+	}
+	{
+		const prefix string = ",\"tier\":"
+		out.RawString(prefix)
+		out.String(string(in.Tier))
+	}
+	if in.TierSince != nil {
+		const prefix string = ",\"tier_since\":"
+		out.RawString(prefix)
+		out.Raw((*in.TierSince).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"accrued\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Accrued))
+	}
+	if in.NextTier != "" {
+		const prefix string = ",\"next_tier\":"
+		out.RawString(prefix)
+		out.String(string(in.NextTier))
+	}
+	if in.ToNextTier != 0 {
+		const prefix string = ",\"to_next_tier\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ToNextTier))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ProfileViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1dc4c543EncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ProfileViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1dc4c543EncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ProfileViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1dc4c543DecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ProfileViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1dc4c543DecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
@@ -0,0 +1,29 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all reconciliation_report_view_model.go
+type ReconciliationReportViewModel struct {
+	RunID      uuid.UUID                         `json:"run_id"`
+	StartedAt  time.Time                         `json:"started_at"`
+	FinishedAt time.Time                         `json:"finished_at"`
+	Checked    int                               `json:"checked"`
+	Failed     int                               `json:"failed"`
+	Corrected  int                               `json:"corrected"`
+	Mismatches []ReconciliationMismatchViewModel `json:"mismatches"`
+}
+
+type ReconciliationMismatchViewModel struct {
+	OrderNumber   string    `json:"order_number"`
+	UserID        uuid.UUID `json:"user_id"`
+	StoredStatus  string    `json:"stored_status"`
+	StoredAccrual float64   `json:"stored_accrual"`
+	RemoteStatus  string    `json:"remote_status"`
+	RemoteAccrual float64   `json:"remote_accrual"`
+	Difference    float64   `json:"difference"`
+	Corrected     bool      `json:"corrected"`
+	DetectedAt    time.Time `json:"detected_at"`
+}
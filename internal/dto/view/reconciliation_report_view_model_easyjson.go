@@ -0,0 +1,291 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *ReconciliationReportViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "run_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.RunID).UnmarshalText(data))
+			}
+		case "started_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.StartedAt).UnmarshalJSON(data))
+			}
+		case "finished_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.FinishedAt).UnmarshalJSON(data))
+			}
+		case "checked":
+			out.Checked = int(in.Int())
+		case "failed":
+			out.Failed = int(in.Int())
+		case "corrected":
+			out.Corrected = int(in.Int())
+		case "mismatches":
+			if in.IsNull() {
+				in.Skip()
+				out.Mismatches = nil
+			} else {
+				in.Delim('[')
+				if out.Mismatches == nil {
+					if !in.IsDelim(']') {
+						out.Mismatches = make([]ReconciliationMismatchViewModel, 0, 0)
+					} else {
+						out.Mismatches = []ReconciliationMismatchViewModel{}
+					}
+				} else {
+					out.Mismatches = (out.Mismatches)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ReconciliationMismatchViewModel
+					(v1).UnmarshalEasyJSON(in)
+					out.Mismatches = append(out.Mismatches, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in ReconciliationReportViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"run_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.RunID).MarshalText())
+	}
+	{
+		const prefix string = ",\"started_at\":"
+		out.RawString(prefix)
+		out.Raw((in.StartedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"finished_at\":"
+		out.RawString(prefix)
+		out.Raw((in.FinishedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"checked\":"
+		out.RawString(prefix)
+		out.Int(int(in.Checked))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int(int(in.Failed))
+	}
+	{
+		const prefix string = ",\"corrected\":"
+		out.RawString(prefix)
+		out.Int(int(in.Corrected))
+	}
+	{
+		const prefix string = ",\"mismatches\":"
+		out.RawString(prefix)
+		if in.Mismatches == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Mismatches {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReconciliationReportViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReconciliationReportViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReconciliationReportViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReconciliationReportViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
+func easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView1(in *jlexer.Lexer, out *ReconciliationMismatchViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "order_number":
+			out.OrderNumber = string(in.String())
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "stored_status":
+			out.StoredStatus = string(in.String())
+		case "stored_accrual":
+			out.StoredAccrual = float64(in.Float64())
+		case "remote_status":
+			out.RemoteStatus = string(in.String())
+		case "remote_accrual":
+			out.RemoteAccrual = float64(in.Float64())
+		case "difference":
+			out.Difference = float64(in.Float64())
+		case "corrected":
+			out.Corrected = bool(in.Bool())
+		case "detected_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DetectedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView1(out *jwriter.Writer, in ReconciliationMismatchViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"order_number\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.OrderNumber))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	{
+		const prefix string = ",\"stored_status\":"
+		out.RawString(prefix)
+		out.String(string(in.StoredStatus))
+	}
+	{
+		const prefix string = ",\"stored_accrual\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.StoredAccrual))
+	}
+	{
+		const prefix string = ",\"remote_status\":"
+		out.RawString(prefix)
+		out.String(string(in.RemoteStatus))
+	}
+	{
+		const prefix string = ",\"remote_accrual\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.RemoteAccrual))
+	}
+	{
+		const prefix string = ",\"difference\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Difference))
+	}
+	{
+		const prefix string = ",\"corrected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Corrected))
+	}
+	{
+		const prefix string = ",\"detected_at\":"
+		out.RawString(prefix)
+		out.Raw((in.DetectedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReconciliationMismatchViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReconciliationMismatchViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE5e5fff6EncodeGithubComRuslanDantsovGophermartInternalDtoView1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReconciliationMismatchViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReconciliationMismatchViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE5e5fff6DecodeGithubComRuslanDantsovGophermartInternalDtoView1(l, v)
+}
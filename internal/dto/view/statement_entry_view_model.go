@@ -0,0 +1,15 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all statement_entry_view_model.go
+type StatementEntryViewModel struct {
+	Type        string    `json:"type"`
+	Amount      float64   `json:"amount"`
+	OrderNumber string    `json:"order,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	Reference   string    `json:"reference,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all tier_change_view_model.go
+type TierChangeViewModel struct {
+	PreviousTier string    `json:"previous_tier,omitempty"`
+	Tier         string    `json:"tier"`
+	Accrued      float64   `json:"accrued"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
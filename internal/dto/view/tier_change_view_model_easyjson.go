@@ -0,0 +1,114 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson9557b61bDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *TierChangeViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "previous_tier":
+			out.PreviousTier = string(in.String())
+		case "tier":
+			out.Tier = string(in.String())
+		case "accrued":
+			out.Accrued = float64(in.Float64())
+		case "changed_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ChangedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9557b61bEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in TierChangeViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.PreviousTier != "" {
+		const prefix string = ",\"previous_tier\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.PreviousTier))
+	}
+	{
+		const prefix string = ",\"tier\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Tier))
+	}
+	{
+		const prefix string = ",\"accrued\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Accrued))
+	}
+	{
+		const prefix string = ",\"changed_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ChangedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TierChangeViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9557b61bEncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TierChangeViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9557b61bEncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TierChangeViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9557b61bDecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TierChangeViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9557b61bDecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
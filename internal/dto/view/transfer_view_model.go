@@ -0,0 +1,13 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all transfer_view_model.go
+type TransferViewModel struct {
+	Sender      string    `json:"sender"`
+	Recipient   string    `json:"recipient"`
+	Sum         float64   `json:"sum"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
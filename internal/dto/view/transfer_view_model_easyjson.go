@@ -0,0 +1,108 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package view
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCc17a8efDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *jlexer.Lexer, out *TransferViewModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sender":
+			out.Sender = string(in.String())
+		case "recipient":
+			out.Recipient = string(in.String())
+		case "sum":
+			out.Sum = float64(in.Float64())
+		case "processed_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ProcessedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCc17a8efEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *jwriter.Writer, in TransferViewModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sender\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Sender))
+	}
+	{
+		const prefix string = ",\"recipient\":"
+		out.RawString(prefix)
+		out.String(string(in.Recipient))
+	}
+	{
+		const prefix string = ",\"sum\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Sum))
+	}
+	{
+		const prefix string = ",\"processed_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ProcessedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferViewModel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCc17a8efEncodeGithubComRuslanDantsovGophermartInternalDtoView(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferViewModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCc17a8efEncodeGithubComRuslanDantsovGophermartInternalDtoView(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferViewModel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCc17a8efDecodeGithubComRuslanDantsovGophermartInternalDtoView(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferViewModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCc17a8efDecodeGithubComRuslanDantsovGophermartInternalDtoView(l, v)
+}
@@ -0,0 +1,15 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"time"
+)
+
+//go:generate easyjson -all webhook_event_view_model.go
+type WebhookEventViewModel struct {
+	ID        uuid.UUID           `json:"id"`
+	Type      string              `json:"type"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      easyjson.RawMessage `json:"data"`
+}
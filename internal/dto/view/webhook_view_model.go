@@ -0,0 +1,15 @@
+package view
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:generate easyjson -all webhook_view_model.go
+type WebhookViewModel struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package view
+
+import (
+	"time"
+)
+
+//go:generate easyjson -all withdraw_hold_view_model.go
+type WithdrawHoldViewModel struct {
+	OrderNumber string     `json:"order"`
+	Sum         float64    `json:"sum"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+}
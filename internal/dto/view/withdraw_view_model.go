@@ -6,7 +6,9 @@ import (
 
 //go:generate easyjson -all withdraw_view_model.go
 type WithdrawViewModel struct {
-	OrderNumber string    `json:"order"`
-	Sum         float64   `json:"sum"`
-	ProcessedAt time.Time `json:"processed_at"`
+	OrderNumber string     `json:"order"`
+	Sum         float64    `json:"sum"`
+	Status      string     `json:"status"`
+	ProcessedAt time.Time  `json:"processed_at"`
+	ReversedAt  *time.Time `json:"reversed_at,omitempty"`
 }
@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -40,10 +41,24 @@ func easyjson5ec55c3eDecodeGithubComRuslanDantsovGophermartInternalDtoView(in *j
 			out.OrderNumber = string(in.String())
 		case "sum":
 			out.Sum = float64(in.Float64())
+		case "status":
+			out.Status = string(in.String())
 		case "processed_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.ProcessedAt).UnmarshalJSON(data))
 			}
+		case "reversed_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ReversedAt = nil
+			} else {
+				if out.ReversedAt == nil {
+					out.ReversedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ReversedAt).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -68,11 +83,21 @@ func easyjson5ec55c3eEncodeGithubComRuslanDantsovGophermartInternalDtoView(out *
 		out.RawString(prefix)
 		out.Float64(float64(in.Sum))
 	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
 	{
 		const prefix string = ",\"processed_at\":"
 		out.RawString(prefix)
 		out.Raw((in.ProcessedAt).MarshalJSON())
 	}
+	if in.ReversedAt != nil {
+		const prefix string = ",\"reversed_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ReversedAt).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
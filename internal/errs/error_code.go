@@ -6,5 +6,23 @@ const (
 	OrderAddedByAnotherUser = "order added By another user"
 	InvalidOrderNumber      = "invalid order number"
 	NotEnoughAccrual        = "not enough accrual on the user account"
+	OrderNotFound           = "order not found"
+	UserNotFound            = "user not found"
+	UserAlreadyExists       = "user already exists"
+	InvalidRole             = "invalid role"
+	InvalidAdjustment       = "invalid balance adjustment"
+	InvalidWebhookURL       = "invalid webhook url"
+	WebhookNotFound         = "webhook not found"
+	WebhookDelivery         = "webhook delivery failed"
+	WithdrawNotFound        = "withdraw not found"
+	WithdrawAlreadyExists   = "withdraw already exists"
+	WithdrawAlreadyReversed = "withdraw already reversed"
+	WithdrawHoldNotFound    = "withdraw hold not found"
+	WithdrawHoldExpired     = "withdraw hold expired"
+	InvalidTransfer         = "invalid transfer"
+	TransferLimitExceeded   = "transfer daily limit exceeded"
+	AccrualUnavailable      = "accrual service is unavailable"
+	UnknownAccrualStatus    = "unknown accrual status"
+	OrderStatusConflict     = "order status transition is not allowed"
 	OrderStatusClient       = "something went wrong on handling response from Accrual service"
 )
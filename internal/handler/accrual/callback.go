@@ -0,0 +1,84 @@
+package accrual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type AccrualDataUpdater interface {
+	UpdateAccrualData(ctx context.Context, number string, accrual float64, status string) error
+}
+
+type AccrualCallbackHandler struct {
+	log                       zap.Logger
+	accrualDataUpdaterService AccrualDataUpdater
+}
+
+func NewAccrualCallbackHandler(log *zap.Logger, accrualDataUpdaterService AccrualDataUpdater) *AccrualCallbackHandler {
+	return &AccrualCallbackHandler{
+		log:                       *log,
+		accrualDataUpdaterService: accrualDataUpdaterService,
+	}
+}
+
+func (h *AccrualCallbackHandler) HandleCallback(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	body, err := ginContext.GetRawData()
+	if err != nil {
+		h.log.Error(fmt.Sprintf("Invalid request body: %s ", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	var accrualResponse view.AccrualResponse
+	if err := easyjson.Unmarshal(body, &accrualResponse); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if accrualResponse.Order == "" || accrualResponse.Accrual < 0 {
+		h.log.Error("Invalid accrual callback", zap.String("order", accrualResponse.Order))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	err = h.accrualDataUpdaterService.UpdateAccrualData(ginContext.Request.Context(), accrualResponse.Order, accrualResponse.Accrual, accrualResponse.Status)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) {
+			switch appErr.Code {
+			case errs.UnknownAccrualStatus:
+				ginContext.JSON(http.StatusBadRequest, gin.H{"error": appErr.Message})
+			case errs.OrderNotFound:
+				ginContext.JSON(http.StatusNotFound, gin.H{"error": appErr.Message})
+			case errs.OrderStatusConflict:
+				ginContext.JSON(http.StatusConflict, gin.H{"error": appErr.Message})
+			default:
+				ginContext.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Message})
+			}
+			h.log.Error(fmt.Sprintf("Accrual callback for order %s is rejected: %s", accrualResponse.Order, err.Error()))
+			return
+		}
+
+		h.log.Error(fmt.Sprintf("Unexpected error: %s", err.Error()))
+		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+		return
+	}
+
+	h.log.Info(fmt.Sprintf("Accrual callback applied for order %s with status %s", accrualResponse.Order, accrualResponse.Status))
+	ginContext.Status(http.StatusOK)
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleAddingBalanceAdjustment(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	var adjustmentCreateCommand command.BalanceAdjustmentCreateCommand
+	if err := ginContext.ShouldBindJSON(&adjustmentCreateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	operatorID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	adjustment, err := h.balanceAdjusterService.AddAdjustment(ginContext.Request.Context(), adjustmentCreateCommand, userID, operatorID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	h.log.Info(fmt.Sprintf("Balance of user %s adjusted by %v by operator %s", userID, adjustment.Amount, operatorID))
+
+	viewModel := view.BalanceAdjustmentViewModel{
+		ID:         adjustment.ID,
+		Amount:     adjustment.Amount,
+		Reason:     adjustment.Reason,
+		Reference:  adjustment.Reference,
+		OperatorID: adjustment.OperatorID,
+		CreatedAt:  adjustment.CreatedAt,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusCreated, viewModel)
+}
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetAccrualProviders(ginContext *gin.Context) {
+	stats := h.accrualProviderStatsGetter.ProviderStats()
+
+	viewModels := make([]view.AccrualProviderViewModel, len(stats))
+	for i, providerStats := range stats {
+		viewModels[i] = view.AccrualProviderViewModel{
+			Name:      providerStats.Name,
+			Rule:      providerStats.Rule,
+			Circuit:   providerStats.Circuit,
+			Polled:    providerStats.Polled,
+			Processed: providerStats.Processed,
+			Failed:    providerStats.Failed,
+			Postponed: providerStats.Postponed,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
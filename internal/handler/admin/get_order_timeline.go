@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetOrderTimeline(ginContext *gin.Context) {
+	timeline, err := h.orderTimelineGetterService.GetOrderTimeline(ginContext.Request.Context(), ginContext.Param("number"))
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	history := make([]view.OrderStatusChangeViewModel, len(timeline.History))
+	for i, record := range timeline.History {
+		history[i] = view.OrderStatusChangeViewModel{
+			Status:    record.Status,
+			Accrual:   record.Accrual,
+			ChangedAt: record.ChangedAt,
+		}
+	}
+
+	viewModel := view.OrderTimelineViewModel{
+		Number:     timeline.Order.Number,
+		UserID:     timeline.Order.UserID,
+		Status:     timeline.Order.Status,
+		Accrual:    timeline.Order.Accrual,
+		UploadedAt: timeline.Order.CreatedAt,
+		History:    history,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetUserBalance(ginContext *gin.Context) {
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	balance, err := h.balanceGetterService.GetBalance(ginContext.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	viewModel := view.BalanceViewModel{
+		Current:   balance.Total,
+		Withdrawn: balance.Withdrawn,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetUserOrders(ginContext *gin.Context) {
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	orders, err := h.userOrdersGetterService.GetOrdersByUser(ginContext.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	if len(orders) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.OrderViewModel, len(orders))
+	for i, order := range orders {
+		viewModels[i] = view.OrderViewModel{
+			Number:     order.Number,
+			Status:     order.Status,
+			Accrual:    order.Accrual,
+			UploadedAt: order.CreatedAt,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
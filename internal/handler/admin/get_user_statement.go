@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetUserStatement(ginContext *gin.Context) {
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	entries, err := h.statementGetterService.GetStatement(ginContext.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	if len(entries) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.StatementEntryViewModel, len(entries))
+	for i, entry := range entries {
+		viewModels[i] = view.StatementEntryViewModel{
+			Type:        entry.Type,
+			Amount:      entry.Amount,
+			OrderNumber: entry.OrderNumber,
+			Reason:      entry.Reason,
+			Reference:   entry.Reference,
+			CreatedAt:   entry.CreatedAt,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleGetUserWithdrawals(ginContext *gin.Context) {
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	withdraws, err := h.userWithdrawsGetterService.GetWithdrawDetailsByUser(ginContext.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	if len(withdraws) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.WithdrawViewModel, len(withdraws))
+	for i, withdraw := range withdraws {
+		viewModels[i] = view.WithdrawViewModel{
+			OrderNumber: withdraw.OrderNumber,
+			Sum:         withdraw.Sum,
+			Status:      withdraw.Status,
+			ProcessedAt: withdraw.CreatedAt,
+			ReversedAt:  withdraw.ReversedAt,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
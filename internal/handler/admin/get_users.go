@@ -0,0 +1,153 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type UserAdministrator interface {
+	GetUsers(ctx context.Context) ([]entity.UserData, error)
+	SetUserRole(ctx context.Context, userID uuid.UUID, role string) error
+}
+
+type UserOrdersGetter interface {
+	GetOrdersByUser(ctx context.Context, userID uuid.UUID) ([]entity.Order, error)
+}
+
+type UserWithdrawsGetter interface {
+	GetWithdrawDetailsByUser(ctx context.Context, userID uuid.UUID) ([]business.WithdrawDetail, error)
+}
+
+type BalanceGetter interface {
+	GetBalance(ctx context.Context, userID uuid.UUID) (*business.Balance, error)
+}
+
+type StatementGetter interface {
+	GetStatement(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error)
+}
+
+type BalanceAdjuster interface {
+	AddAdjustment(ctx context.Context, adjustmentCreateCommand command.BalanceAdjustmentCreateCommand, userID uuid.UUID, operatorID uuid.UUID) (*entity.BalanceAdjustment, error)
+}
+
+type WithdrawReverser interface {
+	ReverseWithdraw(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error)
+}
+
+type OrderTimelineGetter interface {
+	GetOrderTimeline(ctx context.Context, number string) (*business.OrderTimeline, error)
+}
+
+type OrderResyncer interface {
+	SyncOrder(ctx context.Context, orderNumber string) (*view.AccrualResponse, error)
+}
+
+type AccrualProviderStatsGetter interface {
+	ProviderStats() []business.AccrualProviderStats
+}
+
+type AdminHandler struct {
+	log                        zap.Logger
+	userAdministratorService   UserAdministrator
+	userOrdersGetterService    UserOrdersGetter
+	userWithdrawsGetterService UserWithdrawsGetter
+	balanceGetterService       BalanceGetter
+	statementGetterService     StatementGetter
+	balanceAdjusterService     BalanceAdjuster
+	withdrawReverserService    WithdrawReverser
+	orderTimelineGetterService OrderTimelineGetter
+	orderResyncerService       OrderResyncer
+	accrualProviderStatsGetter AccrualProviderStatsGetter
+}
+
+func NewAdminHandler(
+	log *zap.Logger,
+	userAdministratorService UserAdministrator,
+	userOrdersGetterService UserOrdersGetter,
+	userWithdrawsGetterService UserWithdrawsGetter,
+	balanceGetterService BalanceGetter,
+	statementGetterService StatementGetter,
+	balanceAdjusterService BalanceAdjuster,
+	withdrawReverserService WithdrawReverser,
+	orderTimelineGetterService OrderTimelineGetter,
+	orderResyncerService OrderResyncer,
+	accrualProviderStatsGetter AccrualProviderStatsGetter,
+) *AdminHandler {
+	return &AdminHandler{
+		log:                        *log,
+		userAdministratorService:   userAdministratorService,
+		userOrdersGetterService:    userOrdersGetterService,
+		userWithdrawsGetterService: userWithdrawsGetterService,
+		balanceGetterService:       balanceGetterService,
+		statementGetterService:     statementGetterService,
+		balanceAdjusterService:     balanceAdjusterService,
+		withdrawReverserService:    withdrawReverserService,
+		orderTimelineGetterService: orderTimelineGetterService,
+		orderResyncerService:       orderResyncerService,
+		accrualProviderStatsGetter: accrualProviderStatsGetter,
+	}
+}
+
+func (h *AdminHandler) HandleGetUsers(ginContext *gin.Context) {
+	users, err := h.userAdministratorService.GetUsers(ginContext.Request.Context())
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	viewModels := make([]view.AdminUserViewModel, len(users))
+	for i, user := range users {
+		viewModels[i] = view.AdminUserViewModel{
+			ID:        user.ID,
+			Login:     user.Login,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
+
+func (h *AdminHandler) userID(ginContext *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(ginContext.Param("id"))
+	if err != nil {
+		h.log.Error(fmt.Sprintf("Invalid user id: %s", ginContext.Param("id")))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *AdminHandler) handleError(ginContext *gin.Context, err error) {
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case errs.OrderNotFound, errs.UserNotFound, errs.WithdrawNotFound:
+			ginContext.JSON(http.StatusNotFound, gin.H{"error": appErr.Message})
+		case errs.InvalidRole, errs.InvalidAdjustment:
+			ginContext.JSON(http.StatusUnprocessableEntity, gin.H{"error": appErr.Message})
+		case errs.NotEnoughAccrual, errs.WithdrawAlreadyReversed, errs.OrderStatusConflict:
+			ginContext.JSON(http.StatusConflict, gin.H{"error": appErr.Message})
+		case errs.OrderStatusClient:
+			ginContext.JSON(http.StatusBadGateway, gin.H{"error": appErr.Message})
+		default:
+			ginContext.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Message})
+		}
+		h.log.Error(fmt.Sprintf(appErr.Message+", description: %s ", err.Error()))
+		return
+	}
+
+	h.log.Error(fmt.Sprintf("Unexpected error: %s", err.Error()))
+	ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+}
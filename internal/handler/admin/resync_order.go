@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleResyncOrder(ginContext *gin.Context) {
+	accrualResponse, err := h.orderResyncerService.SyncOrder(ginContext.Request.Context(), ginContext.Param("number"))
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, accrualResponse)
+}
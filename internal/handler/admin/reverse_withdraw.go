@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleReversingWithdraw(ginContext *gin.Context) {
+	withdraw, err := h.withdrawReverserService.ReverseWithdraw(ginContext.Request.Context(), ginContext.Param("number"))
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	viewModel := view.WithdrawViewModel{
+		OrderNumber: withdraw.OrderNumber,
+		Sum:         withdraw.Sum,
+		Status:      withdraw.Status,
+		ProcessedAt: withdraw.CreatedAt,
+		ReversedAt:  withdraw.ReversedAt,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
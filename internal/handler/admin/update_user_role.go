@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"net/http"
+)
+
+func (h *AdminHandler) HandleUpdatingUserRole(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	userID, ok := h.userID(ginContext)
+	if !ok {
+		return
+	}
+
+	var roleUpdateCommand command.UserRoleUpdateCommand
+	if err := ginContext.ShouldBindJSON(&roleUpdateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.userAdministratorService.SetUserRole(ginContext.Request.Context(), userID, roleUpdateCommand.Role); err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
@@ -15,15 +15,21 @@ type BalanceGetter interface {
 	GetBalance(ctx context.Context, userID uuid.UUID) (*business.Balance, error)
 }
 
+type StatementGetter interface {
+	GetStatement(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error)
+}
+
 type BalanceHandler struct {
-	log            zap.Logger
-	balanceService BalanceGetter
+	log              zap.Logger
+	balanceService   BalanceGetter
+	statementService StatementGetter
 }
 
-func NewBalanceHandler(log *zap.Logger, balanceService BalanceGetter) *BalanceHandler {
+func NewBalanceHandler(log *zap.Logger, balanceService BalanceGetter, statementService StatementGetter) *BalanceHandler {
 	return &BalanceHandler{
-		log:            *log,
-		balanceService: balanceService,
+		log:              *log,
+		balanceService:   balanceService,
+		statementService: statementService,
 	}
 }
 
@@ -38,8 +44,10 @@ func (h *BalanceHandler) HandleGetBalance(ginContext *gin.Context) {
 	}
 
 	viewModel := view.BalanceViewModel{
-		Current:   balance.Total,
-		Withdrawn: balance.Withdrawn,
+		Current:      balance.Total,
+		Withdrawn:    balance.Withdrawn,
+		Held:         balance.Held,
+		ExpiringSoon: balance.ExpiringSoon,
 	}
 
 	ginContext.Header("Content-Type", "application/json")
@@ -0,0 +1,40 @@
+package balance
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *BalanceHandler) HandleGetStatement(ginContext *gin.Context) {
+	currentUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	entries, err := h.statementService.GetStatement(ginContext.Request.Context(), currentUserID)
+
+	if err != nil {
+		h.log.Error(err.Error())
+		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong on request processing"})
+		return
+	}
+
+	if len(entries) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.StatementEntryViewModel, len(entries))
+	for i, entry := range entries {
+		viewModels[i] = view.StatementEntryViewModel{
+			Type:        entry.Type,
+			Amount:      entry.Amount,
+			OrderNumber: entry.OrderNumber,
+			Reason:      entry.Reason,
+			Reference:   entry.Reference,
+			CreatedAt:   entry.CreatedAt,
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/client"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/service"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type CircuitStateReporter interface {
+	CircuitStates() map[string]string
+}
+
+type HealthHandler struct {
+	log                  zap.Logger
+	accrualCircuitStates CircuitStateReporter
+}
+
+func NewHealthHandler(log *zap.Logger, accrualCircuitStates CircuitStateReporter) *HealthHandler {
+	return &HealthHandler{
+		log:                  *log,
+		accrualCircuitStates: accrualCircuitStates,
+	}
+}
+
+func (h *HealthHandler) HandleGetHealth(ginContext *gin.Context) {
+	circuitStates := h.accrualCircuitStates.CircuitStates()
+	viewModel := view.HealthViewModel{
+		Status:           view.HealthStatusOK,
+		AccrualCircuit:   circuitStates[service.DefaultAccrualProvider],
+		AccrualProviders: circuitStates,
+	}
+
+	for _, state := range circuitStates {
+		if state != client.CircuitClosed {
+			viewModel.Status = view.HealthStatusDegraded
+		}
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
@@ -13,7 +13,15 @@ import (
 
 type CtxUserIDKey struct{}
 
-func AuthMiddleware(jwtSecret string, logger *zap.Logger) gin.HandlerFunc {
+type CtxUserRoleKey struct{}
+
+type CtxUserLoginKey struct{}
+
+type JWTKeyProvider interface {
+	VerificationKeys() [][]byte
+}
+
+func AuthMiddleware(keys JWTKeyProvider, logger *zap.Logger) gin.HandlerFunc {
 	return func(gContext *gin.Context) {
 		authHeader := gContext.GetHeader("Authorization")
 		if len(authHeader) < 7 || authHeader[:7] != "Bearer " {
@@ -28,7 +36,11 @@ func AuthMiddleware(jwtSecret string, logger *zap.Logger) gin.HandlerFunc {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(jwtSecret), nil
+			keySet := jwt.VerificationKeySet{}
+			for _, key := range keys.VerificationKeys() {
+				keySet.Keys = append(keySet.Keys, key)
+			}
+			return keySet, nil
 		})
 
 		if err != nil || !token.Valid {
@@ -50,7 +62,12 @@ func AuthMiddleware(jwtSecret string, logger *zap.Logger) gin.HandlerFunc {
 
 		if ok {
 			userID, _ := uuid.Parse(claims["id"].(string))
-			gContext.Request = gContext.Request.WithContext(context.WithValue(gContext.Request.Context(), CtxUserIDKey{}, userID))
+			role, _ := claims["role"].(string)
+			login, _ := claims["username"].(string)
+			ctx := context.WithValue(gContext.Request.Context(), CtxUserIDKey{}, userID)
+			ctx = context.WithValue(ctx, CtxUserRoleKey{}, role)
+			ctx = context.WithValue(ctx, CtxUserLoginKey{}, login)
+			gContext.Request = gContext.Request.WithContext(ctx)
 		}
 		gContext.Next()
 	}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+func ClientCertMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(gContext *gin.Context) {
+		if gContext.Request.TLS == nil || len(gContext.Request.TLS.VerifiedChains) == 0 {
+			logger.Error("Missing client certificate in request", zap.String("path", gContext.Request.URL.Path))
+			gContext.JSON(http.StatusUnauthorized, gin.H{"error": "Client certificate required"})
+			gContext.Abort()
+			return
+		}
+
+		gContext.Next()
+	}
+}
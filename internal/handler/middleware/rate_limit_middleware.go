@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"go.uber.org/zap"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, method string, path string, client string) (*business.RateLimitDecision, error)
+}
+
+func RateLimitMiddleware(limiter RateLimiter, logger *zap.Logger) gin.HandlerFunc {
+	return func(gContext *gin.Context) {
+		client := "ip:" + gContext.ClientIP()
+		if userID, ok := gContext.Request.Context().Value(CtxUserIDKey{}).(uuid.UUID); ok {
+			client = "user:" + userID.String()
+		}
+
+		decision, err := limiter.Allow(gContext.Request.Context(), gContext.Request.Method, gContext.FullPath(), client)
+		if err != nil {
+			logger.Error("Failed to count request for rate limit", zap.Error(err))
+			gContext.Next()
+			return
+		}
+		if decision == nil {
+			gContext.Next()
+			return
+		}
+
+		reset := strconv.Itoa(int(math.Ceil(decision.Reset.Seconds())))
+		gContext.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		gContext.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		gContext.Header("RateLimit-Reset", reset)
+
+		if !decision.Allowed {
+			logger.Warn("Rate limit exceeded",
+				zap.String("client", client),
+				zap.String("route", gContext.Request.Method+" "+gContext.FullPath()),
+			)
+			gContext.Header("Retry-After", reset)
+			gContext.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			gContext.Abort()
+			return
+		}
+
+		gContext.Next()
+	}
+}
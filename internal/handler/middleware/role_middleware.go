@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"go.uber.org/zap"
+	"net/http"
+	"slices"
+)
+
+type UserRoleFinder interface {
+	GetUserRole(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+func RoleMiddleware(users UserRoleFinder, logger *zap.Logger, allowedRoles ...string) gin.HandlerFunc {
+	return func(gContext *gin.Context) {
+		userID, _ := gContext.Request.Context().Value(CtxUserIDKey{}).(uuid.UUID)
+
+		role, err := users.GetUserRole(gContext.Request.Context(), userID)
+		var appErr *errs.AppError
+		if err != nil && !(errors.As(err, &appErr) && appErr.Code == errs.UserNotFound) {
+			logger.Error("Failed to load user role", zap.Error(err))
+			gContext.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong on request processing"})
+			gContext.Abort()
+			return
+		}
+
+		if !slices.Contains(allowedRoles, role) {
+			logger.Error("Access denied for role",
+				zap.String("role", role),
+				zap.String("path", gContext.FullPath()),
+			)
+			gContext.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			gContext.Abort()
+			return
+		}
+
+		gContext.Next()
+	}
+}
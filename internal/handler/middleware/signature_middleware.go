@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"github.com/gin-gonic/gin"
+	"github.com/ruslanDantsov/gophermart/internal/client"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	AccrualTimestampHeader = "X-Accrual-Timestamp"
+	AccrualSignatureHeader = "X-Accrual-Signature"
+)
+
+func SignatureMiddleware(secret string, tolerance time.Duration, logger *zap.Logger) gin.HandlerFunc {
+	return func(gContext *gin.Context) {
+		timestamp, err := strconv.ParseInt(gContext.GetHeader(AccrualTimestampHeader), 10, 64)
+		if err != nil {
+			logger.Error("Missing or invalid signature timestamp in request")
+			gContext.JSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid signature"})
+			gContext.Abort()
+			return
+		}
+
+		if age := time.Since(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+			logger.Error("Signature timestamp is outside of tolerance", zap.Int64("timestamp", timestamp))
+			gContext.JSON(http.StatusUnauthorized, gin.H{"error": "Signature has expired"})
+			gContext.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(gContext.Request.Body)
+		if err != nil {
+			logger.Error("Failed to read request body", zap.Error(err))
+			gContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			gContext.Abort()
+			return
+		}
+		gContext.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected := client.SignWebhookPayload(secret, timestamp, body)
+		if !hmac.Equal([]byte(expected), []byte(gContext.GetHeader(AccrualSignatureHeader))) {
+			logger.Error("Invalid signature in request")
+			gContext.JSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid signature"})
+			gContext.Abort()
+			return
+		}
+
+		gContext.Next()
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
 	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"go.uber.org/zap"
 	"net/http"
@@ -20,17 +22,23 @@ type OrderGetter interface {
 	GetOrders(ctx context.Context) ([]entity.Order, error)
 }
 
+type OrderEventSubscriber interface {
+	Subscribe(userID uuid.UUID) (<-chan business.OrderStatusEvent, func())
+}
+
 type OrderHandler struct {
-	log                 zap.Logger
-	orderCreatorService OrderCreator
-	orderGetterService  OrderGetter
+	log                  zap.Logger
+	orderCreatorService  OrderCreator
+	orderGetterService   OrderGetter
+	orderEventSubscriber OrderEventSubscriber
 }
 
-func NewOrderHandler(log *zap.Logger, orderCreatorService OrderCreator, orderGetterService OrderGetter) *OrderHandler {
+func NewOrderHandler(log *zap.Logger, orderCreatorService OrderCreator, orderGetterService OrderGetter, orderEventSubscriber OrderEventSubscriber) *OrderHandler {
 	return &OrderHandler{
-		log:                 *log,
-		orderCreatorService: orderCreatorService,
-		orderGetterService:  orderGetterService,
+		log:                  *log,
+		orderCreatorService:  orderCreatorService,
+		orderGetterService:   orderGetterService,
+		orderEventSubscriber: orderEventSubscriber,
 	}
 }
 
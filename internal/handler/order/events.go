@@ -0,0 +1,65 @@
+package order
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	orderStatusEventName    = "order_status"
+	eventsHeartbeatInterval = 15 * time.Second
+)
+
+func (h *OrderHandler) HandleOrderEvents(ginContext *gin.Context) {
+	currentUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+
+	events, unsubscribe := h.orderEventSubscriber.Subscribe(currentUserID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	controller := http.NewResponseController(ginContext.Writer)
+	if err := controller.SetReadDeadline(time.Time{}); err != nil {
+		h.log.Warn(fmt.Sprintf("Unable to clear read deadline of order events stream: %s", err.Error()))
+	}
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		h.log.Warn(fmt.Sprintf("Unable to clear write deadline of order events stream: %s", err.Error()))
+	}
+
+	ginContext.Header("Content-Type", "text/event-stream")
+	ginContext.Header("Cache-Control", "no-cache")
+	ginContext.Header("Connection", "keep-alive")
+	ginContext.Header("X-Accel-Buffering", "no")
+
+	h.log.Info(fmt.Sprintf("Order events stream opened for user %s", currentUserID))
+
+	ginContext.Stream(func(w io.Writer) bool {
+		select {
+		case <-ginContext.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			ginContext.SSEvent(orderStatusEventName, view.OrderEventViewModel{
+				Number:    event.Number,
+				Status:    event.Status,
+				Accrual:   event.Accrual,
+				UpdatedAt: event.UpdatedAt,
+			})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
+
+	h.log.Info(fmt.Sprintf("Order events stream closed for user %s", currentUserID))
+}
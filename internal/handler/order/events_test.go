@@ -0,0 +1,79 @@
+package order
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type stubOrderEventSubscriber struct {
+	userID uuid.UUID
+	events chan business.OrderStatusEvent
+}
+
+func (s *stubOrderEventSubscriber) Subscribe(userID uuid.UUID) (<-chan business.OrderStatusEvent, func()) {
+	s.userID = userID
+	return s.events, func() {}
+}
+
+func TestOrderHandler_HandleOrderEvents(t *testing.T) {
+	userID := uuid.New()
+	subscriber := &stubOrderEventSubscriber{events: make(chan business.OrderStatusEvent, 1)}
+	subscriber.events <- business.OrderStatusEvent{
+		UserID:    userID,
+		Number:    "79927398713",
+		Status:    "PROCESSED",
+		Accrual:   500,
+		UpdatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+	handler := NewOrderHandler(zap.NewNop(), nil, nil, subscriber)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/user/orders/events", func(ginContext *gin.Context) {
+		ctx := context.WithValue(ginContext.Request.Context(), middleware.CtxUserIDKey{}, userID)
+		ginContext.Request = ginContext.Request.WithContext(ctx)
+	}, handler.HandleOrderEvents)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/user/orders/events", nil)
+	require.NoError(t, err)
+
+	response, err := server.Client().Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, userID, subscriber.userID)
+
+	var lines []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() && scanner.Text() != "" {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, "event:order_status", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "data:"))
+	assert.JSONEq(t,
+		`{"number":"79927398713","status":"PROCESSED","accrual":500,"updated_at":"2025-07-01T12:00:00Z"}`,
+		strings.TrimPrefix(lines[1], "data:"),
+	)
+}
@@ -0,0 +1,52 @@
+package profile
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ProfileGetter interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*business.Profile, error)
+}
+
+type ProfileHandler struct {
+	log                  zap.Logger
+	profileGetterService ProfileGetter
+}
+
+func NewProfileHandler(log *zap.Logger, profileGetterService ProfileGetter) *ProfileHandler {
+	return &ProfileHandler{
+		log:                  *log,
+		profileGetterService: profileGetterService,
+	}
+}
+
+func (h *ProfileHandler) HandleGetProfile(ginContext *gin.Context) {
+	currentUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	currentUserLogin, _ := ginContext.Request.Context().Value(middleware.CtxUserLoginKey{}).(string)
+
+	profile, err := h.profileGetterService.GetProfile(ginContext.Request.Context(), currentUserID)
+	if err != nil {
+		h.log.Error(err.Error())
+		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong on request processing"})
+		return
+	}
+
+	viewModel := view.ProfileViewModel{
+		Login:      currentUserLogin,
+		Tier:       profile.Tier,
+		TierSince:  profile.TierSince,
+		Accrued:    profile.Accrued,
+		NextTier:   profile.NextTier,
+		ToNextTier: profile.ToNextTier,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
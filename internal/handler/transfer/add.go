@@ -0,0 +1,75 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type TransferCreator interface {
+	AddTransfer(ctx context.Context, transferCreateCommand command.TransferCreateCommand, senderID uuid.UUID, senderLogin string) (*entity.Transfer, error)
+}
+
+type TransferHandler struct {
+	log                    zap.Logger
+	transferCreatorService TransferCreator
+}
+
+func NewTransferHandler(log *zap.Logger, transferCreatorService TransferCreator) *TransferHandler {
+	return &TransferHandler{
+		log:                    *log,
+		transferCreatorService: transferCreatorService,
+	}
+}
+
+func (h *TransferHandler) HandleAddingTransfer(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	var transferCreateCommand command.TransferCreateCommand
+
+	if err := ginContext.ShouldBindJSON(&transferCreateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	authUserLogin, _ := ginContext.Request.Context().Value(middleware.CtxUserLoginKey{}).(string)
+	_, err := h.transferCreatorService.AddTransfer(ginContext.Request.Context(), transferCreateCommand, authUserID, authUserLogin)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) {
+			switch appErr.Code {
+			case errs.NotEnoughAccrual:
+				ginContext.JSON(http.StatusPaymentRequired, gin.H{"error": appErr.Message})
+			case errs.UserNotFound:
+				ginContext.JSON(http.StatusNotFound, gin.H{"error": appErr.Message})
+			case errs.InvalidTransfer, errs.TransferLimitExceeded:
+				ginContext.JSON(http.StatusUnprocessableEntity, gin.H{"error": appErr.Message})
+			default:
+				ginContext.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Message})
+			}
+			h.log.Error(fmt.Sprintf(appErr.Message+", description: %s ", err.Error()))
+			return
+		}
+
+		h.log.Error(fmt.Sprintf("Unexpected error: %s", err.Error()))
+		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+		return
+	}
+
+	ginContext.Status(http.StatusOK)
+}
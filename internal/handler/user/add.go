@@ -10,6 +10,7 @@ import (
 	"github.com/mailru/easyjson"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
 	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"github.com/ruslanDantsov/gophermart/internal/service"
 	"go.uber.org/zap"
@@ -23,7 +24,7 @@ type UserManager interface {
 }
 
 type AuthManager interface {
-	GenerateJWT(id uuid.UUID, username string) (*service.TokenResult, error)
+	GenerateJWT(id uuid.UUID, username string, role string) (*service.TokenResult, error)
 }
 
 type UserHandler struct {
@@ -71,10 +72,18 @@ func (h *UserHandler) HandleRegisterUser(ginContext *gin.Context) {
 	userData, err := h.userManager.AddUser(ginContext.Request.Context(), userCreateCommand)
 	if err != nil {
 		h.log.Error("Failed to save user: " + err.Error())
+
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.UserAlreadyExists {
+			ginContext.JSON(http.StatusConflict, gin.H{"error": appErr.Message})
+			return
+		}
+
 		ginContext.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	tokenResult, err := h.authManager.GenerateJWT(userData.ID, userData.Login)
+	tokenResult, err := h.authManager.GenerateJWT(userData.ID, userData.Login, userData.Role)
 	if err != nil {
 		h.log.Error("Failed to generate token: " + err.Error())
 		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockUserManager struct {
+	mock.Mock
+}
+
+func (m *MockUserManager) AddUser(ctx context.Context, userCreateCommand command.UserCreateCommand) (*entity.UserData, error) {
+	args := m.Called(ctx, userCreateCommand)
+	userData, _ := args.Get(0).(*entity.UserData)
+	return userData, args.Error(1)
+}
+
+func (m *MockUserManager) FindByLoginAndPassword(ctx context.Context, login string, password string) (*entity.UserData, error) {
+	args := m.Called(ctx, login, password)
+	userData, _ := args.Get(0).(*entity.UserData)
+	return userData, args.Error(1)
+}
+
+type MockAuthManager struct {
+	mock.Mock
+}
+
+func (m *MockAuthManager) GenerateJWT(id uuid.UUID, username string, role string) (*service.TokenResult, error) {
+	args := m.Called(id, username, role)
+	tokenResult, _ := args.Get(0).(*service.TokenResult)
+	return tokenResult, args.Error(1)
+}
+
+func registerUser(handler *UserHandler) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/user/register", handler.HandleRegisterUser)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/api/user/register", strings.NewReader(`{"login":"alice","password":"secret"}`))
+	request.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestUserHandler_HandleRegisterUser(t *testing.T) {
+	userCreateCommand := command.UserCreateCommand{Login: "alice", Password: "secret"}
+
+	t.Run("registers user and returns token", func(t *testing.T) {
+		userManager := new(MockUserManager)
+		authManager := new(MockAuthManager)
+		userData := &entity.UserData{ID: uuid.New(), Login: "alice", Role: entity.UserRoleUser}
+
+		userManager.On("AddUser", mock.Anything, userCreateCommand).Return(userData, nil)
+		authManager.On("GenerateJWT", userData.ID, "alice", entity.UserRoleUser).Return(&service.TokenResult{AccessToken: "token"}, nil)
+
+		recorder := registerUser(NewUserHandler(zap.NewNop(), userManager, authManager))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "Bearer token", recorder.Header().Get("Authorization"))
+	})
+
+	t.Run("returns conflict for taken login", func(t *testing.T) {
+		userManager := new(MockUserManager)
+		authManager := new(MockAuthManager)
+
+		userManager.On("AddUser", mock.Anything, userCreateCommand).
+			Return(nil, errs.New(errs.UserAlreadyExists, "login not unique ", errors.New("duplicate key")))
+
+		recorder := registerUser(NewUserHandler(zap.NewNop(), userManager, authManager))
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Authorization"))
+		authManager.AssertNotCalled(t, "GenerateJWT", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stops after failing to save user", func(t *testing.T) {
+		userManager := new(MockUserManager)
+		authManager := new(MockAuthManager)
+
+		userManager.On("AddUser", mock.Anything, userCreateCommand).Return(nil, errors.New("connection refused"))
+
+		recorder := registerUser(NewUserHandler(zap.NewNop(), userManager, authManager))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		authManager.AssertNotCalled(t, "GenerateJWT", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -45,7 +45,7 @@ func (h *UserHandler) HandleAuthentication(ginContext *gin.Context) {
 		return
 	}
 
-	tokenResult, err := h.authManager.GenerateJWT(userData.ID, userData.Login)
+	tokenResult, err := h.authManager.GenerateJWT(userData.ID, userData.Login, userData.Role)
 	if err != nil {
 		h.log.Error("Failed to generate token: " + err.Error())
 		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type WebhookManager interface {
+	AddWebhook(ctx context.Context, webhookCreateCommand command.WebhookCreateCommand, authUserID uuid.UUID) (*entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, webhookUpdateCommand command.WebhookUpdateCommand, authUserID uuid.UUID) (*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID, authUserID uuid.UUID) error
+	GetWebhook(ctx context.Context, id uuid.UUID, authUserID uuid.UUID) (*entity.Webhook, error)
+	GetWebhooks(ctx context.Context, authUserID uuid.UUID) ([]entity.Webhook, error)
+}
+
+type WebhookHandler struct {
+	log            zap.Logger
+	webhookManager WebhookManager
+}
+
+func NewWebhookHandler(log *zap.Logger, webhookManager WebhookManager) *WebhookHandler {
+	return &WebhookHandler{
+		log:            *log,
+		webhookManager: webhookManager,
+	}
+}
+
+func (h *WebhookHandler) HandleAddingWebhook(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	var webhookCreateCommand command.WebhookCreateCommand
+	if err := ginContext.ShouldBindJSON(&webhookCreateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	webhook, err := h.webhookManager.AddWebhook(ginContext.Request.Context(), webhookCreateCommand, authUserID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	viewModel := toWebhookViewModel(*webhook)
+	viewModel.Secret = webhook.Secret
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusCreated, viewModel)
+}
+
+func (h *WebhookHandler) handleError(ginContext *gin.Context, err error) {
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case errs.InvalidWebhookURL:
+			ginContext.JSON(http.StatusUnprocessableEntity, gin.H{"error": appErr.Message})
+		case errs.WebhookNotFound:
+			ginContext.JSON(http.StatusNotFound, gin.H{"error": appErr.Message})
+		default:
+			ginContext.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Message})
+		}
+		h.log.Error(fmt.Sprintf(appErr.Message+", description: %s ", err.Error()))
+		return
+	}
+
+	h.log.Error(fmt.Sprintf("Unexpected error: %s", err.Error()))
+	ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+}
+
+func (h *WebhookHandler) webhookID(ginContext *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ginContext.Param("id"))
+	if err != nil {
+		h.log.Error(fmt.Sprintf("Invalid webhook id: %s", ginContext.Param("id")))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func toWebhookViewModel(webhook entity.Webhook) view.WebhookViewModel {
+	return view.WebhookViewModel{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
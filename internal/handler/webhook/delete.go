@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WebhookHandler) HandleDeletingWebhook(ginContext *gin.Context) {
+	id, ok := h.webhookID(ginContext)
+	if !ok {
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	if err := h.webhookManager.DeleteWebhook(ginContext.Request.Context(), id, authUserID); err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	ginContext.Status(http.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WebhookHandler) HandleGetWebhook(ginContext *gin.Context) {
+	id, ok := h.webhookID(ginContext)
+	if !ok {
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	webhook, err := h.webhookManager.GetWebhook(ginContext.Request.Context(), id, authUserID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, toWebhookViewModel(*webhook))
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WebhookHandler) HandleGetWebhooks(ginContext *gin.Context) {
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	webhooks, err := h.webhookManager.GetWebhooks(ginContext.Request.Context(), authUserID)
+
+	if err != nil {
+		h.log.Error(err.Error())
+		ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong on request processing"})
+		return
+	}
+
+	if len(webhooks) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.WebhookViewModel, len(webhooks))
+	for i, webhook := range webhooks {
+		viewModels[i] = toWebhookViewModel(webhook)
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WebhookHandler) HandleUpdatingWebhook(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	id, ok := h.webhookID(ginContext)
+	if !ok {
+		return
+	}
+
+	var webhookUpdateCommand command.WebhookUpdateCommand
+	if err := ginContext.ShouldBindJSON(&webhookUpdateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	webhook, err := h.webhookManager.UpdateWebhook(ginContext.Request.Context(), id, webhookUpdateCommand, authUserID)
+	if err != nil {
+		h.handleError(ginContext, err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, toWebhookViewModel(*webhook))
+}
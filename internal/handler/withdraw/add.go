@@ -23,17 +23,26 @@ type WithdrawGetter interface {
 	GetWithdrawDetails(ctx context.Context) ([]business.WithdrawDetail, error)
 }
 
+type WithdrawHolder interface {
+	AddHold(ctx context.Context, withdrawCreateCommand command.WithdrawCreateCommand, authUserID uuid.UUID) (*entity.WithdrawHold, error)
+	CaptureHold(ctx context.Context, orderNumber string, authUserID uuid.UUID) (*entity.Withdraw, error)
+	ReleaseHold(ctx context.Context, orderNumber string, authUserID uuid.UUID) (*entity.WithdrawHold, error)
+	GetActiveHolds(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error)
+}
+
 type WithdrawHandler struct {
 	log                    zap.Logger
 	withdrawCreatorService WithdrawCreator
 	withdrawGetterService  WithdrawGetter
+	withdrawHolderService  WithdrawHolder
 }
 
-func NewWithdrawHandler(log *zap.Logger, withdrawCreatorService WithdrawCreator, withdrawGetterService WithdrawGetter) *WithdrawHandler {
+func NewWithdrawHandler(log *zap.Logger, withdrawCreatorService WithdrawCreator, withdrawGetterService WithdrawGetter, withdrawHolderService WithdrawHolder) *WithdrawHandler {
 	return &WithdrawHandler{
 		log:                    *log,
 		withdrawCreatorService: withdrawCreatorService,
 		withdrawGetterService:  withdrawGetterService,
+		withdrawHolderService:  withdrawHolderService,
 	}
 }
 func (h *WithdrawHandler) HandleAddingWithdraw(ginContext *gin.Context) {
@@ -60,10 +69,8 @@ func (h *WithdrawHandler) HandleAddingWithdraw(ginContext *gin.Context) {
 			switch appErr.Code {
 			case errs.NotEnoughAccrual:
 				ginContext.JSON(http.StatusPaymentRequired, gin.H{"error": appErr.Message})
-			case errs.OrderAddedByCurrentUser:
-				ginContext.Writer.WriteHeader(http.StatusOK)
-			case errs.OrderAddedByAnotherUser:
-				ginContext.Writer.WriteHeader(http.StatusConflict)
+			case errs.WithdrawAlreadyExists:
+				ginContext.JSON(http.StatusConflict, gin.H{"error": appErr.Message})
 			case errs.InvalidOrderNumber:
 				ginContext.JSON(http.StatusUnprocessableEntity, gin.H{"error": appErr.Message})
 			default:
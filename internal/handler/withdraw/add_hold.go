@@ -0,0 +1,75 @@
+package withdraw
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"net/http"
+)
+
+func (h *WithdrawHandler) HandleAddingHold(ginContext *gin.Context) {
+	contentType := ginContext.GetHeader("Content-Type")
+	if contentType != "application/json" {
+		h.log.Error(fmt.Sprintf("Unsupported content type: %s ", contentType))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported content type"})
+		return
+	}
+
+	var withdrawCreateCommand command.WithdrawCreateCommand
+
+	if err := ginContext.ShouldBindJSON(&withdrawCreateCommand); err != nil {
+		h.log.Error(fmt.Sprintf("Invalid JSON: %s", err.Error()))
+		ginContext.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	hold, err := h.withdrawHolderService.AddHold(ginContext.Request.Context(), withdrawCreateCommand, authUserID)
+	if err != nil {
+		h.handleHoldError(ginContext, err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusCreated, toWithdrawHoldViewModel(*hold))
+}
+
+func (h *WithdrawHandler) handleHoldError(ginContext *gin.Context, err error) {
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case errs.NotEnoughAccrual:
+			ginContext.JSON(http.StatusPaymentRequired, gin.H{"error": appErr.Message})
+		case errs.WithdrawAlreadyExists, errs.WithdrawHoldExpired:
+			ginContext.JSON(http.StatusConflict, gin.H{"error": appErr.Message})
+		case errs.WithdrawHoldNotFound:
+			ginContext.JSON(http.StatusNotFound, gin.H{"error": appErr.Message})
+		case errs.InvalidOrderNumber:
+			ginContext.JSON(http.StatusUnprocessableEntity, gin.H{"error": appErr.Message})
+		default:
+			ginContext.JSON(http.StatusInternalServerError, gin.H{"error": appErr.Message})
+		}
+		h.log.Error(fmt.Sprintf(appErr.Message+", description: %s ", err.Error()))
+		return
+	}
+
+	h.log.Error(fmt.Sprintf("Unexpected error: %s", err.Error()))
+	ginContext.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+}
+
+func toWithdrawHoldViewModel(hold entity.WithdrawHold) view.WithdrawHoldViewModel {
+	return view.WithdrawHoldViewModel{
+		OrderNumber: hold.OrderNumber,
+		Sum:         hold.Sum,
+		Status:      hold.Status,
+		CreatedAt:   hold.CreatedAt,
+		ExpiresAt:   hold.ExpiresAt,
+		ResolvedAt:  hold.ResolvedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package withdraw
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WithdrawHandler) HandleCapturingHold(ginContext *gin.Context) {
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	withdraw, err := h.withdrawHolderService.CaptureHold(ginContext.Request.Context(), ginContext.Param("number"), authUserID)
+	if err != nil {
+		h.handleHoldError(ginContext, err)
+		return
+	}
+
+	viewModel := view.WithdrawViewModel{
+		OrderNumber: withdraw.OrderNumber,
+		Sum:         withdraw.Sum,
+		Status:      withdraw.Status,
+		ProcessedAt: withdraw.CreatedAt,
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModel)
+}
@@ -25,7 +25,9 @@ func (h *WithdrawHandler) HandleGetWithdraws(ginContext *gin.Context) {
 		viewModels[i] = view.WithdrawViewModel{
 			OrderNumber: withdraw.OrderNumber,
 			Sum:         withdraw.Sum,
+			Status:      withdraw.Status,
 			ProcessedAt: withdraw.CreatedAt,
+			ReversedAt:  withdraw.ReversedAt,
 		}
 	}
 
@@ -0,0 +1,31 @@
+package withdraw
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WithdrawHandler) HandleGetHolds(ginContext *gin.Context) {
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	holds, err := h.withdrawHolderService.GetActiveHolds(ginContext.Request.Context(), authUserID)
+	if err != nil {
+		h.handleHoldError(ginContext, err)
+		return
+	}
+
+	if len(holds) == 0 {
+		ginContext.JSON(http.StatusNoContent, gin.H{})
+		return
+	}
+
+	viewModels := make([]view.WithdrawHoldViewModel, len(holds))
+	for i, hold := range holds {
+		viewModels[i] = toWithdrawHoldViewModel(hold)
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, viewModels)
+}
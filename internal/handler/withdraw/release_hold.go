@@ -0,0 +1,20 @@
+package withdraw
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"net/http"
+)
+
+func (h *WithdrawHandler) HandleReleasingHold(ginContext *gin.Context) {
+	authUserID := ginContext.Request.Context().Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	hold, err := h.withdrawHolderService.ReleaseHold(ginContext.Request.Context(), ginContext.Param("number"), authUserID)
+	if err != nil {
+		h.handleHoldError(ginContext, err)
+		return
+	}
+
+	ginContext.Header("Content-Type", "application/json")
+	ginContext.JSON(http.StatusOK, toWithdrawHoldViewModel(*hold))
+}
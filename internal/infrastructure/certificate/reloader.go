@@ -0,0 +1,106 @@
+package certificate
+
+import (
+	"context"
+	"crypto/tls"
+	"go.uber.org/zap"
+	"os"
+	"sync"
+	"time"
+)
+
+type Reloader struct {
+	certFile    string
+	keyFile     string
+	log         *zap.Logger
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	modTime     time.Time
+}
+
+func NewReloader(certFile string, keyFile string, log *zap.Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		log:      log,
+	}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.certificate, nil
+}
+
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reloadIfChanged()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Reloader) reloadIfChanged() {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		r.log.Error("Failed to check TLS certificate files", zap.Error(err))
+		return
+	}
+
+	r.mu.RLock()
+	changed := !modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if !changed {
+		return
+	}
+
+	if err := r.load(modTime); err != nil {
+		r.log.Error("Failed to reload TLS certificate, keeping the current one", zap.Error(err))
+		return
+	}
+	r.log.Info("TLS certificate reloaded", zap.String("cert", r.certFile))
+}
+
+func (r *Reloader) load(modTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.certificate = &certificate
+	r.modTime = modTime
+	return nil
+}
+
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
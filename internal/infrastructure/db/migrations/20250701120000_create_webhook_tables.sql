@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE webhook (
+    id       uuid NOT NULL PRIMARY KEY,
+    user_id  uuid NOT NULL REFERENCES "user_data"(id),
+    url      varchar(2048) NOT NULL,
+    secret   varchar(128) NOT NULL,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX webhook_user_index on webhook USING btree(user_id);
+
+CREATE TABLE outbox_event (
+    id          uuid NOT NULL PRIMARY KEY,
+    event_type  varchar(64) NOT NULL,
+    user_id     uuid NOT NULL REFERENCES "user_data"(id),
+    payload     jsonb NOT NULL,
+    created_at  TIMESTAMP NOT NULL,
+    dispatched_at TIMESTAMP
+);
+
+CREATE INDEX outbox_event_undispatched_index on outbox_event USING btree(created_at) WHERE dispatched_at IS NULL;
+CREATE INDEX outbox_event_user_index on outbox_event USING btree(user_id, created_at);
+
+CREATE TABLE webhook_delivery (
+    id          uuid NOT NULL PRIMARY KEY,
+    webhook_id  uuid NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event_id    uuid NOT NULL REFERENCES outbox_event(id),
+    status      varchar(32) NOT NULL,
+    attempts    integer NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error  text,
+    created_at  TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX webhook_delivery_pending_index on webhook_delivery USING btree(next_attempt_at) WHERE status = 'PENDING';
+
+-- +goose Down
+DROP INDEX IF EXISTS webhook_delivery_pending_index;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP INDEX IF EXISTS outbox_event_user_index;
+DROP INDEX IF EXISTS outbox_event_undispatched_index;
+DROP TABLE IF EXISTS outbox_event;
+DROP INDEX IF EXISTS webhook_user_index;
+DROP TABLE IF EXISTS webhook;
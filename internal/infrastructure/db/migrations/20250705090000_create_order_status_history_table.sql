@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE order_status_history (
+    id        uuid NOT NULL PRIMARY KEY,
+    order_id  uuid NOT NULL REFERENCES "order"(id) ON DELETE CASCADE,
+    status    varchar(32) NOT NULL,
+    accrual   numeric(12, 4) DEFAULT 0,
+    changed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX order_status_history_order_index on order_status_history USING btree(order_id, changed_at);
+
+INSERT INTO order_status_history (id, order_id, status, accrual, changed_at)
+SELECT gen_random_uuid(), o.id, o.status, o.accrual, COALESCE(o.created_at, now())
+FROM "order" o;
+
+-- +goose Down
+DROP INDEX IF EXISTS order_status_history_order_index;
+DROP TABLE IF EXISTS order_status_history;
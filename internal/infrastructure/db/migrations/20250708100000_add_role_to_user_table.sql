@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE user_data ADD COLUMN role varchar(32) NOT NULL DEFAULT 'USER';
+
+-- +goose Down
+ALTER TABLE user_data DROP COLUMN IF EXISTS role;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE balance_adjustment (
+    id          uuid NOT NULL PRIMARY KEY,
+    user_id     uuid NOT NULL REFERENCES "user_data"(id),
+    amount      numeric(12, 4) NOT NULL CHECK (amount <> 0),
+    reason      text NOT NULL CHECK (length(trim(reason)) > 0),
+    operator_id uuid NOT NULL REFERENCES "user_data"(id),
+    reference   varchar(256),
+    created_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX balance_adjustment_user_index on balance_adjustment USING btree(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS balance_adjustment_user_index;
+DROP TABLE IF EXISTS balance_adjustment;
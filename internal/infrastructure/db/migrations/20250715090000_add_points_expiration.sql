@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE "order" ADD COLUMN processed_at TIMESTAMP;
+
+UPDATE "order" o
+SET processed_at = COALESCE(
+    (SELECT max(h.changed_at) FROM order_status_history h WHERE h.order_id = o.id AND h.status = 'PROCESSED'),
+    o.created_at)
+WHERE o.status = 'PROCESSED';
+
+CREATE INDEX order_processed_index on "order" USING btree(user_id, processed_at) WHERE status = 'PROCESSED';
+
+ALTER TABLE balance_adjustment ADD COLUMN kind varchar(32) NOT NULL DEFAULT 'MANUAL';
+ALTER TABLE balance_adjustment ALTER COLUMN operator_id DROP NOT NULL;
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM balance_adjustment WHERE operator_id IS NULL) THEN
+        RAISE EXCEPTION 'balance_adjustment has system rows without operator_id, they are part of user balances and cannot be rolled back';
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE balance_adjustment ALTER COLUMN operator_id SET NOT NULL;
+ALTER TABLE balance_adjustment DROP COLUMN IF EXISTS kind;
+DROP INDEX IF EXISTS order_processed_index;
+ALTER TABLE "order" DROP COLUMN IF EXISTS processed_at;
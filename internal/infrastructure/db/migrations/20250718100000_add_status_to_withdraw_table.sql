@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE withdraw ADD COLUMN status varchar(32) NOT NULL DEFAULT 'COMPLETED';
+ALTER TABLE withdraw ADD COLUMN reversed_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE withdraw DROP COLUMN IF EXISTS reversed_at;
+ALTER TABLE withdraw DROP COLUMN IF EXISTS status;
@@ -0,0 +1,59 @@
+-- +goose Up
+ALTER TABLE withdraw ADD COLUMN order_number varchar(256);
+ALTER TABLE withdraw ADD COLUMN user_id uuid REFERENCES "user_data"(id);
+
+UPDATE withdraw w
+SET order_number = o.number, user_id = o.user_id
+FROM "order" o
+WHERE w.order_id = o.id;
+
+ALTER TABLE withdraw ALTER COLUMN order_number SET NOT NULL;
+ALTER TABLE withdraw ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE withdraw DROP COLUMN order_id;
+
+CREATE UNIQUE INDEX withdraw_order_number_index on withdraw USING btree(order_number);
+CREATE INDEX withdraw_user_index on withdraw USING btree(user_id);
+
+-- +goose StatementBegin
+DO $$
+DECLARE
+    kept record;
+BEGIN
+    FOR kept IN
+        SELECT o.number, o.status, o.accrual
+        FROM "order" o
+        JOIN withdraw w ON w.order_number = o.number
+        WHERE o.status <> 'NEW' OR coalesce(o.accrual, 0) <> 0
+    LOOP
+        RAISE NOTICE 'order % shares its number with a withdrawal and is kept (status %, accrual %)',
+            kept.number, kept.status, kept.accrual;
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
+
+DELETE FROM "order" o
+USING withdraw w
+WHERE w.order_number = o.number
+  AND o.status = 'NEW'
+  AND coalesce(o.accrual, 0) = 0;
+
+-- +goose Down
+ALTER TABLE withdraw ADD COLUMN order_id uuid REFERENCES "order"(id);
+
+INSERT INTO "order" (id, number, status, accrual, created_at, user_id)
+SELECT gen_random_uuid(), w.order_number, 'INVALID', 0, w.created_at, w.user_id
+FROM withdraw w
+ON CONFLICT (number) DO NOTHING;
+
+UPDATE withdraw w
+SET order_id = o.id
+FROM "order" o
+WHERE o.number = w.order_number;
+
+ALTER TABLE withdraw ALTER COLUMN order_id SET NOT NULL;
+
+DROP INDEX IF EXISTS withdraw_user_index;
+DROP INDEX IF EXISTS withdraw_order_number_index;
+ALTER TABLE withdraw DROP COLUMN IF EXISTS user_id;
+ALTER TABLE withdraw DROP COLUMN IF EXISTS order_number;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE withdraw_hold (
+    id           uuid NOT NULL PRIMARY KEY,
+    user_id      uuid NOT NULL REFERENCES "user_data"(id),
+    order_number varchar(256) NOT NULL,
+    sum          numeric(12, 4) NOT NULL CHECK (sum > 0),
+    status       varchar(32) NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    expires_at   TIMESTAMP NOT NULL,
+    resolved_at  TIMESTAMP
+);
+
+CREATE UNIQUE INDEX withdraw_hold_active_order_number_index on withdraw_hold USING btree(order_number) WHERE status = 'ACTIVE';
+CREATE INDEX withdraw_hold_user_index on withdraw_hold USING btree(user_id);
+CREATE INDEX withdraw_hold_expires_index on withdraw_hold USING btree(expires_at) WHERE status = 'ACTIVE';
+
+-- +goose Down
+DROP TABLE IF EXISTS withdraw_hold;
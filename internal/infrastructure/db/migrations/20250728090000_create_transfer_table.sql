@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE transfer (
+    id           uuid NOT NULL PRIMARY KEY,
+    sender_id    uuid NOT NULL REFERENCES "user_data"(id),
+    recipient_id uuid NOT NULL REFERENCES "user_data"(id),
+    sum          numeric(12, 4) NOT NULL CHECK (sum > 0),
+    created_at   TIMESTAMP NOT NULL,
+    CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX transfer_sender_index on transfer USING btree(sender_id, created_at);
+CREATE INDEX transfer_recipient_index on transfer USING btree(recipient_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS transfer;
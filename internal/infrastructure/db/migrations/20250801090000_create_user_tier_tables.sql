@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE user_tier (
+    user_id    uuid NOT NULL PRIMARY KEY REFERENCES "user_data"(id) ON DELETE CASCADE,
+    tier       varchar(32) NOT NULL,
+    accrued    numeric(12, 4) NOT NULL DEFAULT 0,
+    tier_since TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE user_tier_history (
+    id            uuid NOT NULL PRIMARY KEY,
+    user_id       uuid NOT NULL REFERENCES "user_data"(id) ON DELETE CASCADE,
+    previous_tier varchar(32),
+    tier          varchar(32) NOT NULL,
+    accrued       numeric(12, 4) NOT NULL DEFAULT 0,
+    changed_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX user_tier_history_user_index on user_tier_history USING btree(user_id, changed_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_tier_history;
+DROP TABLE IF EXISTS user_tier;
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX balance_adjustment_reference_index on balance_adjustment USING btree(kind, reference);
+
+-- +goose Down
+DROP INDEX IF EXISTS balance_adjustment_reference_index;
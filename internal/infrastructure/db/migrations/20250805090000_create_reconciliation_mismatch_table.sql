@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE reconciliation_mismatch (
+    id             uuid NOT NULL PRIMARY KEY,
+    run_id         uuid NOT NULL,
+    order_id       uuid NOT NULL REFERENCES "order"(id),
+    order_number   varchar(256) NOT NULL,
+    user_id        uuid NOT NULL REFERENCES "user_data"(id),
+    stored_status  varchar(32) NOT NULL,
+    stored_accrual numeric(12, 4) NOT NULL,
+    remote_status  varchar(32) NOT NULL,
+    remote_accrual numeric(12, 4) NOT NULL,
+    difference     numeric(12, 4) NOT NULL,
+    corrected      boolean NOT NULL DEFAULT false,
+    detected_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX reconciliation_mismatch_run_index on reconciliation_mismatch USING btree(run_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS reconciliation_mismatch_run_index;
+DROP TABLE IF EXISTS reconciliation_mismatch;
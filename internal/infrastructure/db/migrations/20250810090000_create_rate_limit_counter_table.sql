@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE rate_limit_counter (
+    key          varchar(512) NOT NULL,
+    window_start TIMESTAMP NOT NULL,
+    hits         integer NOT NULL,
+    expires_at   TIMESTAMP NOT NULL,
+    PRIMARY KEY (key, window_start)
+);
+
+CREATE INDEX rate_limit_counter_expires_at_index on rate_limit_counter USING btree(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_limit_counter;
@@ -0,0 +1,45 @@
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsPrivate() &&
+		!addr.IsUnspecified()
+}
+
+func CheckHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !IsPublic(addr) {
+			return fmt.Errorf("host %s resolves to non-public address %s", host, addr)
+		}
+	}
+	return nil
+}
+
+func Control(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !IsPublic(addrPort.Addr()) {
+		return fmt.Errorf("connection to non-public address %s is not allowed", addrPort.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package netguard
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublic(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:2800::1":     true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"fd00::1":          false,
+		"0.0.0.0":          false,
+		"::":               false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, IsPublic(netip.MustParseAddr(address)), address)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, CheckHost(ctx, "93.184.216.34"))
+	assert.Error(t, CheckHost(ctx, "localhost"))
+	assert.Error(t, CheckHost(ctx, "169.254.169.254"))
+}
+
+func TestControl(t *testing.T) {
+	assert.NoError(t, Control("tcp4", "93.184.216.34:443", nil))
+	assert.Error(t, Control("tcp4", "127.0.0.1:8080", nil))
+	assert.Error(t, Control("tcp6", "[::1]:8080", nil))
+}
@@ -0,0 +1,24 @@
+package pubsub
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+)
+
+type LocalPublisher struct {
+	broker *OrderEventBroker
+}
+
+func NewLocalPublisher(broker *OrderEventBroker) *LocalPublisher {
+	return &LocalPublisher{
+		broker: broker,
+	}
+}
+
+func (p *LocalPublisher) Publish(_ context.Context, event business.OrderStatusEvent) {
+	p.broker.Publish(event)
+}
+
+func (p *LocalPublisher) Listen(ctx context.Context) {
+	<-ctx.Done()
+}
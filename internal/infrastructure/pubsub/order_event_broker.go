@@ -0,0 +1,58 @@
+package pubsub
+
+import (
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"sync"
+)
+
+const subscriberBufferSize = 16
+
+type OrderEventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan business.OrderStatusEvent]struct{}
+}
+
+func NewOrderEventBroker() *OrderEventBroker {
+	return &OrderEventBroker{
+		subscribers: make(map[uuid.UUID]map[chan business.OrderStatusEvent]struct{}),
+	}
+}
+
+func (b *OrderEventBroker) Subscribe(userID uuid.UUID) (<-chan business.OrderStatusEvent, func()) {
+	ch := make(chan business.OrderStatusEvent, subscriberBufferSize)
+
+	b.mu.Lock()
+	if _, ok := b.subscribers[userID]; !ok {
+		b.subscribers[userID] = make(map[chan business.OrderStatusEvent]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *OrderEventBroker) Publish(event business.OrderStatusEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.UserID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderStatusEvent(userID uuid.UUID, number string) business.OrderStatusEvent {
+	return business.OrderStatusEvent{
+		UserID:    userID,
+		Number:    number,
+		Status:    "PROCESSED",
+		Accrual:   100,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TestOrderEventBroker(t *testing.T) {
+	t.Run("delivers published event to subscriber", func(t *testing.T) {
+		broker := NewOrderEventBroker()
+		userID := uuid.New()
+		events, unsubscribe := broker.Subscribe(userID)
+		defer unsubscribe()
+
+		event := newOrderStatusEvent(userID, "79927398713")
+		broker.Publish(event)
+
+		select {
+		case received := <-events:
+			assert.Equal(t, event, received)
+		case <-time.After(time.Second):
+			t.Fatal("event was not delivered")
+		}
+	})
+
+	t.Run("closes channel on unsubscribe", func(t *testing.T) {
+		broker := NewOrderEventBroker()
+		userID := uuid.New()
+		events, unsubscribe := broker.Subscribe(userID)
+
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.NotPanics(t, func() { broker.Publish(newOrderStatusEvent(userID, "79927398713")) })
+		assert.Empty(t, broker.subscribers)
+	})
+
+	t.Run("delivers events only to subscribers of the same user", func(t *testing.T) {
+		broker := NewOrderEventBroker()
+		aliceID := uuid.New()
+		bobID := uuid.New()
+		aliceEvents, unsubscribeAlice := broker.Subscribe(aliceID)
+		defer unsubscribeAlice()
+		bobEvents, unsubscribeBob := broker.Subscribe(bobID)
+		defer unsubscribeBob()
+
+		broker.Publish(newOrderStatusEvent(aliceID, "79927398713"))
+
+		require.Len(t, aliceEvents, 1)
+		assert.Empty(t, bobEvents)
+	})
+
+	t.Run("does not block on slow subscriber", func(t *testing.T) {
+		broker := NewOrderEventBroker()
+		userID := uuid.New()
+		slowEvents, unsubscribeSlow := broker.Subscribe(userID)
+		defer unsubscribeSlow()
+		events, unsubscribe := broker.Subscribe(userID)
+		defer unsubscribe()
+
+		done := make(chan int)
+		go func() {
+			received := 0
+			for i := 0; i < subscriberBufferSize*2; i++ {
+				broker.Publish(newOrderStatusEvent(userID, "79927398713"))
+				<-events
+				received++
+			}
+			done <- received
+		}()
+
+		select {
+		case received := <-done:
+			assert.Equal(t, subscriberBufferSize*2, received)
+		case <-time.After(time.Second):
+			t.Fatal("publish blocked on slow subscriber")
+		}
+		assert.Len(t, slowEvents, subscriberBufferSize)
+	})
+}
@@ -0,0 +1,119 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	OrderStatusChannel     = "order_status_changed"
+	listenerReconnectDelay = 5 * time.Second
+)
+
+type orderStatusNotification struct {
+	Origin    uuid.UUID `json:"origin"`
+	UserID    uuid.UUID `json:"user_id"`
+	Number    string    `json:"number"`
+	Status    string    `json:"status"`
+	Accrual   float64   `json:"accrual"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PostgreNotifyBridge struct {
+	storage    *postgre.PostgreStorage
+	broker     *OrderEventBroker
+	instanceID uuid.UUID
+	log        *zap.Logger
+}
+
+func NewPostgreNotifyBridge(storage *postgre.PostgreStorage, broker *OrderEventBroker, log *zap.Logger) *PostgreNotifyBridge {
+	return &PostgreNotifyBridge{
+		storage:    storage,
+		broker:     broker,
+		instanceID: uuid.New(),
+		log:        log,
+	}
+}
+
+func (b *PostgreNotifyBridge) Publish(ctx context.Context, event business.OrderStatusEvent) {
+	b.broker.Publish(event)
+
+	payload, err := json.Marshal(orderStatusNotification{
+		Origin:    b.instanceID,
+		UserID:    event.UserID,
+		Number:    event.Number,
+		Status:    event.Status,
+		Accrual:   event.Accrual,
+		UpdatedAt: event.UpdatedAt,
+	})
+	if err != nil {
+		b.log.Error("Failed to marshal order status notification", zap.Error(err))
+		return
+	}
+
+	if _, err := b.storage.GetExecutor(ctx).Exec(ctx, query.NotifyChannel, OrderStatusChannel, string(payload)); err != nil {
+		b.log.Error("Failed to notify other instances about order status change",
+			zap.String("order", event.Number),
+			zap.Error(err),
+		)
+	}
+}
+
+func (b *PostgreNotifyBridge) Listen(ctx context.Context) {
+	for {
+		if err := b.listen(ctx); err != nil && ctx.Err() == nil {
+			b.log.Error("Order status listener failed, reconnecting", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			b.log.Info("Order status listener received shutdown signal")
+			return
+		case <-time.After(listenerReconnectDelay):
+		}
+	}
+}
+
+func (b *PostgreNotifyBridge) listen(ctx context.Context) error {
+	conn, err := b.storage.Conn.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, fmt.Sprintf(query.ListenChannel, OrderStatusChannel)); err != nil {
+		return fmt.Errorf("listen %s: %w", OrderStatusChannel, err)
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		var payload orderStatusNotification
+		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
+			b.log.Error("Failed to parse order status notification", zap.Error(err))
+			continue
+		}
+
+		if payload.Origin == b.instanceID {
+			continue
+		}
+
+		b.broker.Publish(business.OrderStatusEvent{
+			UserID:    payload.UserID,
+			Number:    payload.Number,
+			Status:    payload.Status,
+			Accrual:   payload.Accrual,
+			UpdatedAt: payload.UpdatedAt,
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+type ctxTxKey struct{}
+
+type OrderRecord struct {
+	entity.Order
+	ProcessedAt *time.Time
+}
+
+type Tables struct {
+	Users                    map[uuid.UUID]entity.UserData
+	Orders                   map[uuid.UUID]OrderRecord
+	OrderStatusHistory       []entity.OrderStatusHistory
+	Withdraws                map[uuid.UUID]entity.Withdraw
+	WithdrawHolds            map[uuid.UUID]entity.WithdrawHold
+	BalanceAdjustments       []entity.BalanceAdjustment
+	Transfers                []entity.Transfer
+	UserTiers                map[uuid.UUID]entity.UserTier
+	UserTierHistory          []entity.UserTierHistory
+	Webhooks                 map[uuid.UUID]entity.Webhook
+	OutboxEvents             map[uuid.UUID]entity.OutboxEvent
+	WebhookDeliveries        map[uuid.UUID]entity.WebhookDelivery
+	ReconciliationMismatches []entity.ReconciliationMismatch
+}
+
+func newTables() *Tables {
+	return &Tables{
+		Users:             make(map[uuid.UUID]entity.UserData),
+		Orders:            make(map[uuid.UUID]OrderRecord),
+		Withdraws:         make(map[uuid.UUID]entity.Withdraw),
+		WithdrawHolds:     make(map[uuid.UUID]entity.WithdrawHold),
+		UserTiers:         make(map[uuid.UUID]entity.UserTier),
+		Webhooks:          make(map[uuid.UUID]entity.Webhook),
+		OutboxEvents:      make(map[uuid.UUID]entity.OutboxEvent),
+		WebhookDeliveries: make(map[uuid.UUID]entity.WebhookDelivery),
+	}
+}
+
+func (t *Tables) clone() *Tables {
+	return &Tables{
+		Users:                    maps.Clone(t.Users),
+		Orders:                   maps.Clone(t.Orders),
+		OrderStatusHistory:       slices.Clone(t.OrderStatusHistory),
+		Withdraws:                maps.Clone(t.Withdraws),
+		WithdrawHolds:            maps.Clone(t.WithdrawHolds),
+		BalanceAdjustments:       slices.Clone(t.BalanceAdjustments),
+		Transfers:                slices.Clone(t.Transfers),
+		UserTiers:                maps.Clone(t.UserTiers),
+		UserTierHistory:          slices.Clone(t.UserTierHistory),
+		Webhooks:                 maps.Clone(t.Webhooks),
+		OutboxEvents:             maps.Clone(t.OutboxEvents),
+		WebhookDeliveries:        maps.Clone(t.WebhookDeliveries),
+		ReconciliationMismatches: slices.Clone(t.ReconciliationMismatches),
+	}
+}
+
+type MemoryStorage struct {
+	mu     sync.Mutex
+	tables *Tables
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		tables: newTables(),
+	}
+}
+
+func (s *MemoryStorage) inTx(ctx context.Context) bool {
+	storage, ok := ctx.Value(ctxTxKey{}).(*MemoryStorage)
+	return ok && storage == s
+}
+
+func (s *MemoryStorage) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	snapshot := s.tables.clone()
+	committed := false
+
+	defer func() {
+		if !committed {
+			s.tables = snapshot
+		}
+		s.mu.Unlock()
+	}()
+
+	if err := fn(context.WithValue(ctx, ctxTxKey{}, s)); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+func (s *MemoryStorage) Do(ctx context.Context, fn func(tables *Tables) error) error {
+	if s.inTx(ctx) {
+		return fn(s.tables)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fn(s.tables)
+}
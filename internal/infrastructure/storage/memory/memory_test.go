@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveUser(ctx context.Context, s *MemoryStorage, login string) error {
+	return s.Do(ctx, func(tables *Tables) error {
+		id := uuid.New()
+		tables.Users[id] = entity.UserData{ID: id, Login: login}
+		return nil
+	})
+}
+
+func countUsers(s *MemoryStorage) int {
+	count := 0
+	_ = s.Do(context.Background(), func(tables *Tables) error {
+		count = len(tables.Users)
+		return nil
+	})
+	return count
+}
+
+func TestMemoryStorage_WithTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commits on success", func(t *testing.T) {
+		s := NewMemoryStorage()
+
+		err := s.WithTx(ctx, func(ctx context.Context) error {
+			return saveUser(ctx, s, "alice")
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, countUsers(s))
+	})
+
+	t.Run("rolls back on error including nested calls", func(t *testing.T) {
+		s := NewMemoryStorage()
+		require.NoError(t, saveUser(ctx, s, "alice"))
+		errAbort := errors.New("abort")
+
+		err := s.WithTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, s.WithTx(ctx, func(ctx context.Context) error {
+				return saveUser(ctx, s, "bob")
+			}))
+			assert.Equal(t, 2, len(s.tables.Users), "row must be visible inside the transaction")
+
+			return errAbort
+		})
+
+		require.ErrorIs(t, err, errAbort)
+		assert.Equal(t, 1, countUsers(s))
+	})
+
+	t.Run("rolls back and releases the lock on panic", func(t *testing.T) {
+		s := NewMemoryStorage()
+
+		assert.Panics(t, func() {
+			_ = s.WithTx(ctx, func(ctx context.Context) error {
+				_ = saveUser(ctx, s, "alice")
+				panic("boom")
+			})
+		})
+
+		assert.Equal(t, 0, countUsers(s))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/db"
 	"go.uber.org/zap"
 )
 
@@ -35,10 +36,6 @@ type PostgreStorage struct {
 }
 
 func NewPostgreStorage(ctx context.Context, log *zap.Logger, connectionString string) (*PostgreStorage, error) {
-	if err := applyMigrations(connectionString); err != nil {
-		return nil, err
-	}
-
 	conn, err := pgxpool.New(ctx, connectionString)
 
 	if err != nil {
@@ -87,19 +84,41 @@ func (s *PostgreStorage) WithTx(ctx context.Context, fn func(ctx context.Context
 	return nil
 }
 
-func applyMigrations(connectionString string) error {
+const (
+	MigrateUp      = "up"
+	MigrateDown    = "down"
+	MigrateStatus  = "status"
+	MigrateVersion = "version"
+)
+
+func IsMigrateCommand(command string) bool {
+	switch command {
+	case MigrateUp, MigrateDown, MigrateStatus, MigrateVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+func Migrate(ctx context.Context, connectionString string, command string) error {
+	if !IsMigrateCommand(command) {
+		return fmt.Errorf("unsupported migrate command: %s", command)
+	}
+
 	sqlDB, err := sql.Open("pgx", connectionString)
 	if err != nil {
 		return err
 	}
 	defer sqlDB.Close()
 
+	goose.SetBaseFS(db.Migrations)
+
 	if err := goose.SetDialect("postgres"); err != nil {
 		return err
 	}
 
-	if err := goose.Up(sqlDB, "internal/infrastructure/db/migrations"); err != nil {
-		return fmt.Errorf("unable to apply migrations: %w", err)
+	if err := goose.RunContext(ctx, command, sqlDB, db.MigrationsDir); err != nil {
+		return fmt.Errorf("unable to run migrate %s: %w", command, err)
 	}
 
 	return nil
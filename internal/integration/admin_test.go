@@ -0,0 +1,41 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGophermart_RoleChangeAppliesToIssuedTokens(t *testing.T) {
+	databaseURI := newTestDatabase(t)
+	accrual := newAccrualStub(t)
+	baseURL := startApp(t, databaseURI, accrual.URL)
+
+	alice := &apiClient{t: t, baseURL: baseURL}
+	alice.authenticate("/api/user/register", "alice", "secret")
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, databaseURI)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	setRole := func(role string) {
+		_, err := conn.Exec(ctx, "UPDATE user_data SET role = $1 WHERE login = $2", role, "alice")
+		require.NoError(t, err)
+	}
+
+	resp, _ := alice.do(http.MethodGet, "/api/admin/users", "", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	setRole("ADMIN")
+	resp, _ = alice.do(http.MethodGet, "/api/admin/users", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	setRole("USER")
+	resp, _ = alice.do(http.MethodGet, "/api/admin/users", "", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "demoted admin must lose access with the token issued before")
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/client"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGophermart_AccrualAndWithdrawFlow(t *testing.T) {
+	forEachBackend(t, testAccrualAndWithdrawFlow)
+}
+
+func testAccrualAndWithdrawFlow(t *testing.T, databaseURI string) {
+	accrual := newAccrualStub(t, "Bork:10:%")
+	baseURL := startApp(t, databaseURI, accrual.URL)
+
+	accrual.registerOrder(t, "12345678903", `[{"description":"Чайник Bork","price":7000}]`)
+
+	client := &apiClient{t: t, baseURL: baseURL}
+	client.authenticate("/api/user/register", "alice", "secret")
+
+	other := &apiClient{t: t, baseURL: baseURL}
+	other.authenticate("/api/user/register", "bob", "secret")
+
+	resp, _ := client.do(http.MethodPost, "/api/user/register", "application/json", `{"login":"alice","password":"other"}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, _ = client.do(http.MethodPost, "/api/user/login", "application/json", `{"login":"alice","password":"wrong"}`)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	client.authenticate("/api/user/login", "alice", "secret")
+
+	resp, _ = client.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	resp, _ = client.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678903")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = other.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678903")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, _ = client.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678904")
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		resp, body := client.do(http.MethodGet, "/api/user/orders", "", "")
+		if resp.StatusCode != http.StatusOK {
+			return false
+		}
+
+		var orders []view.OrderViewModel
+		require.NoError(t, json.Unmarshal(body, &orders))
+		return len(orders) == 1 && orders[0].Status == view.AccrualOrderProcessedStatus && orders[0].Accrual == 700
+	}, 15*time.Second, 200*time.Millisecond)
+
+	balance := getBalance(t, client)
+	assert.Equal(t, float64(700), balance.Current)
+	assert.Equal(t, float64(0), balance.Withdrawn)
+
+	resp, _ = client.do(http.MethodPost, "/api/user/balance/withdraw", "application/json", `{"order":"79927398713","sum":250}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = client.do(http.MethodPost, "/api/user/balance/withdraw", "application/json", `{"order":"2377225624","sum":1000}`)
+	assert.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+
+	balance = getBalance(t, client)
+	assert.Equal(t, float64(450), balance.Current)
+	assert.Equal(t, float64(250), balance.Withdrawn)
+
+	resp, body := client.do(http.MethodGet, "/api/user/withdrawals", "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var withdrawals []view.WithdrawViewModel
+	require.NoError(t, json.Unmarshal(body, &withdrawals))
+	require.Len(t, withdrawals, 1)
+	assert.Equal(t, "79927398713", withdrawals[0].OrderNumber)
+	assert.Equal(t, float64(250), withdrawals[0].Sum)
+
+	resp, _ = other.do(http.MethodGet, "/api/user/withdrawals", "", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	time.Sleep(1500 * time.Millisecond)
+	assert.Zero(t, accrual.pollCount("79927398713"))
+}
+
+func TestGophermart_InvalidAccrualOrder(t *testing.T) {
+	forEachBackend(t, testInvalidAccrualOrder)
+}
+
+func testInvalidAccrualOrder(t *testing.T, databaseURI string) {
+	accrual := newAccrualStub(t, "Bork:10:%")
+	baseURL := startApp(t, databaseURI, accrual.URL)
+
+	accrual.registerOrder(t, "4561261212345467", `[{"description":"Table","price":100}]`)
+
+	client := &apiClient{t: t, baseURL: baseURL}
+	client.authenticate("/api/user/register", "carol", "secret")
+
+	resp, _ := client.do(http.MethodPost, "/api/user/orders", "text/plain", "4561261212345467")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		_, body := client.do(http.MethodGet, "/api/user/orders", "", "")
+
+		var orders []view.OrderViewModel
+		if err := json.Unmarshal(body, &orders); err != nil {
+			return false
+		}
+		return len(orders) == 1 && orders[0].Status == view.AccrualOrderInvalidStatus
+	}, 15*time.Second, 200*time.Millisecond)
+
+	assert.Equal(t, float64(0), getBalance(t, client).Current)
+
+	resp, _ = client.do(http.MethodGet, "/api/user/balance", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	unauthenticated := &apiClient{t: t, baseURL: baseURL}
+	resp, _ = unauthenticated.do(http.MethodGet, "/api/user/balance", "", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGophermart_AccrualProviders(t *testing.T) {
+	forEachBackend(t, testAccrualProviders)
+}
+
+func testAccrualProviders(t *testing.T, databaseURI string) {
+	accrual := newAccrualStub(t, "Bork:10:%")
+	partner := newAccrualStub(t, "Bork:20:%")
+	baseURL := startApp(t, databaseURI, accrual.URL, "--accrual-provider", "partner|"+partner.URL+"|prefix=4561")
+
+	accrual.registerOrder(t, "12345678903", `[{"description":"Чайник Bork","price":1000}]`)
+	partner.registerOrder(t, "4561261212345467", `[{"description":"Чайник Bork","price":1000}]`)
+
+	client := &apiClient{t: t, baseURL: baseURL}
+	client.authenticate("/api/user/register", "dave", "secret")
+
+	for _, number := range []string{"12345678903", "4561261212345467"} {
+		resp, _ := client.do(http.MethodPost, "/api/user/orders", "text/plain", number)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	}
+
+	require.Eventually(t, func() bool {
+		return getBalance(t, client).Current == 300
+	}, 15*time.Second, 200*time.Millisecond)
+
+	assert.Zero(t, accrual.pollCount("4561261212345467"))
+	assert.Zero(t, partner.pollCount("12345678903"))
+
+	resp, body := client.do(http.MethodGet, "/api/health", "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var health view.HealthViewModel
+	require.NoError(t, easyjson.Unmarshal(body, &health))
+	assert.Equal(t, map[string]string{"default": "closed", "partner": "closed"}, health.AccrualProviders)
+}
+
+func TestGophermart_AccrualCallback(t *testing.T) {
+	forEachBackend(t, testAccrualCallback)
+}
+
+func testAccrualCallback(t *testing.T, databaseURI string) {
+	const secret = "callback-secret"
+
+	accrual := newAccrualStub(t)
+	baseURL := startApp(t, databaseURI, accrual.URL, "--accrual-callback-secret", secret, "--accrual-fallback-interval", "3600")
+
+	client := &apiClient{t: t, baseURL: baseURL}
+	client.authenticate("/api/user/register", "erin", "secret")
+
+	resp, _ := client.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678903")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	processed := `{"order":"12345678903","status":"PROCESSED","accrual":150}`
+	assert.Equal(t, http.StatusUnauthorized, sendAccrualCallback(t, baseURL, "wrong-secret", processed))
+	assert.Equal(t, http.StatusOK, sendAccrualCallback(t, baseURL, secret, processed))
+	assert.Equal(t, http.StatusOK, sendAccrualCallback(t, baseURL, secret, processed))
+	assert.Equal(t, http.StatusNotFound, sendAccrualCallback(t, baseURL, secret, `{"order":"4561261212345467","status":"PROCESSED","accrual":10}`))
+	assert.Equal(t, http.StatusBadRequest, sendAccrualCallback(t, baseURL, secret, `{"order":"12345678903","status":"DONE"}`))
+	assert.Equal(t, http.StatusConflict, sendAccrualCallback(t, baseURL, secret, `{"order":"12345678903","status":"PROCESSING"}`))
+	assert.Equal(t, http.StatusConflict, sendAccrualCallback(t, baseURL, secret, `{"order":"12345678903","status":"PROCESSED","accrual":900}`))
+
+	assert.Equal(t, float64(150), getBalance(t, client).Current)
+	assert.Zero(t, accrual.pollCount("12345678903"))
+}
+
+func sendAccrualCallback(t *testing.T, baseURL string, secret string, body string) int {
+	t.Helper()
+
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/internal/accrual/callback", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.AccrualTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(middleware.AccrualSignatureHeader, client.SignWebhookPayload(secret, timestamp, []byte(body)))
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func getBalance(t *testing.T, client *apiClient) view.BalanceViewModel {
+	t.Helper()
+
+	resp, body := client.do(http.MethodGet, "/api/user/balance", "", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var balance view.BalanceViewModel
+	require.NoError(t, easyjson.Unmarshal(body, &balance))
+	return balance
+}
+
+func TestGophermart_RateLimits(t *testing.T) {
+	forEachBackend(t, testRateLimits)
+}
+
+func testRateLimits(t *testing.T, databaseURI string) {
+	store := "local"
+	if databaseURI != "" {
+		store = "postgres"
+	}
+
+	accrual := newAccrualStub(t)
+	baseURL := startApp(t, databaseURI, accrual.URL,
+		"--rate-limit-store", store,
+		"--rate-limit", "POST|/api/user/login|2/1h",
+		"--rate-limit", "POST|/api/user/orders|1/1h",
+	)
+
+	alice := &apiClient{t: t, baseURL: baseURL}
+	alice.authenticate("/api/user/register", "frank", "secret")
+	bob := &apiClient{t: t, baseURL: baseURL}
+	bob.authenticate("/api/user/register", "grace", "secret")
+
+	login := `{"login":"frank","password":"secret"}`
+	resp, _ := alice.do(http.MethodPost, "/api/user/login", "application/json", login)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header.Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, resp.Header.Get("RateLimit-Reset"))
+
+	resp, _ = alice.do(http.MethodPost, "/api/user/login", "application/json", login)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = alice.do(http.MethodPost, "/api/user/login", "application/json", login)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, resp.Header.Get("RateLimit-Reset"), resp.Header.Get("Retry-After"))
+
+	resp, _ = alice.do(http.MethodPost, "/api/user/orders", "text/plain", "12345678903")
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp, _ = alice.do(http.MethodPost, "/api/user/orders", "text/plain", "79927398713")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	resp, _ = bob.do(http.MethodPost, "/api/user/orders", "text/plain", "79927398713")
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "orders are limited per user")
+
+	resp, _ = bob.do(http.MethodGet, "/api/user/orders", "", "")
+	assert.Empty(t, resp.Header.Get("RateLimit-Limit"), "routes without a limit are not counted")
+}
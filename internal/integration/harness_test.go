@@ -0,0 +1,316 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/ruslanDantsov/gophermart/internal/accrualsim"
+	"github.com/ruslanDantsov/gophermart/internal/app"
+	"github.com/ruslanDantsov/gophermart/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const DatabaseURIEnv = "GOPHERMART_TEST_DATABASE_URI"
+
+const (
+	postgresImage        = "postgres:16-alpine"
+	postgresStartTimeout = 30 * time.Second
+	appStartTimeout      = 10 * time.Second
+)
+
+var (
+	adminDatabaseURI string
+	skipReason       string
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runSuite(m))
+}
+
+func runSuite(m *testing.M) int {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+
+	databaseURI, cleanup, err := startPostgres()
+	if err != nil {
+		skipReason = err.Error()
+	} else {
+		adminDatabaseURI = databaseURI
+		defer cleanup()
+	}
+
+	return m.Run()
+}
+
+func startPostgres() (string, func(), error) {
+	if uri := os.Getenv(DatabaseURIEnv); uri != "" {
+		return uri, func() {}, waitForPostgres(uri)
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", nil, fmt.Errorf("docker is not available and %s is not set", DatabaseURIEnv)
+	}
+
+	out, err := exec.Command("docker", "run", "-d", "--rm",
+		"-e", "POSTGRES_PASSWORD=postgres",
+		"-e", "POSTGRES_DB=gophermart",
+		"-p", "127.0.0.1::5432",
+		postgresImage,
+	).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to start postgres container: %w", err)
+	}
+
+	containerID := strings.TrimSpace(string(out))
+	cleanup := func() {
+		_ = exec.Command("docker", "rm", "-f", containerID).Run()
+	}
+
+	out, err = exec.Command("docker", "port", containerID, "5432/tcp").Output()
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("unable to resolve postgres port: %w", err)
+	}
+
+	hostPort := strings.TrimSpace(strings.Split(string(out), "\n")[0])
+	uri := fmt.Sprintf("postgres://postgres:postgres@%s/gophermart?sslmode=disable", hostPort)
+
+	if err := waitForPostgres(uri); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return uri, cleanup, nil
+}
+
+func waitForPostgres(uri string) error {
+	deadline := time.Now().Add(postgresStartTimeout)
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		conn, err := pgx.Connect(ctx, uri)
+		if err == nil {
+			err = conn.Ping(ctx)
+			conn.Close(ctx)
+		}
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("postgres is not reachable: %w", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func newTestDatabase(t *testing.T) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("integration tests are skipped in short mode")
+	}
+	if adminDatabaseURI == "" {
+		t.Skipf("integration tests are skipped: %s", skipReason)
+	}
+
+	name := "gophermart_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	ctx := context.Background()
+	adminConn, err := pgx.Connect(ctx, adminDatabaseURI)
+	require.NoError(t, err)
+	defer adminConn.Close(ctx)
+
+	_, err = adminConn.Exec(ctx, "CREATE DATABASE "+name)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(ctx, adminDatabaseURI)
+		if err != nil {
+			return
+		}
+		defer conn.Close(ctx)
+		_, _ = conn.Exec(ctx, "DROP DATABASE IF EXISTS "+name+" WITH (FORCE)")
+	})
+
+	parsed, err := url.Parse(adminDatabaseURI)
+	require.NoError(t, err)
+	parsed.Path = "/" + name
+
+	return parsed.String()
+}
+
+func forEachBackend(t *testing.T, test func(t *testing.T, databaseURI string)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, "")
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		test(t, newTestDatabase(t))
+	})
+}
+
+type accrualStub struct {
+	*httptest.Server
+	mu     sync.Mutex
+	polled map[string]int
+}
+
+func newAccrualStub(t *testing.T, rewardRules ...string) *accrualStub {
+	t.Helper()
+
+	simulator, err := accrualsim.NewSimulator(&config.AccrualSimConfig{RewardRules: rewardRules}, zap.NewNop())
+	require.NoError(t, err)
+
+	stub := &accrualStub{polled: make(map[string]int)}
+	router := simulator.Router()
+
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/orders/") {
+			stub.mu.Lock()
+			stub.polled[strings.TrimPrefix(r.URL.Path, "/api/orders/")]++
+			stub.mu.Unlock()
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(stub.Close)
+
+	return stub
+}
+
+func (s *accrualStub) registerOrder(t *testing.T, number string, goods string) {
+	t.Helper()
+
+	body := fmt.Sprintf(`{"order":%q,"goods":%s}`, number, goods)
+	resp, err := http.Post(s.URL+"/api/orders", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
+
+func (s *accrualStub) pollCount(number string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polled[number]
+}
+
+func startApp(t *testing.T, databaseURI string, accrualURL string, extraArgs ...string) string {
+	t.Helper()
+
+	address := freeAddress(t)
+	args := append([]string{
+		"-a", address,
+		"-d", databaseURI,
+		"-r", accrualURL,
+		"-i", "1",
+		"-s", "5",
+		"-l", "ERROR",
+	}, extraArgs...)
+
+	cfg, err := config.NewConfig(args)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gophermartApp, err := app.NewGophermartApp(ctx, cfg, zap.NewNop())
+	if err != nil {
+		cancel()
+		require.NoError(t, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gophermartApp.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	baseURL := "http://" + address
+	require.Eventually(t, func() bool {
+		conn, err := net.DialTimeout("tcp", address, 100*time.Millisecond)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, appStartTimeout, 50*time.Millisecond)
+
+	return baseURL
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+type apiClient struct {
+	t          *testing.T
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func (c *apiClient) do(method, path, contentType, body string) (*http.Response, []byte) {
+	c.t.Helper()
+
+	req, err := http.NewRequest(method, c.baseURL+path, strings.NewReader(body))
+	require.NoError(c.t, err)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", c.token)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(resp.Body)
+	require.NoError(c.t, err)
+
+	return resp, buf.Bytes()
+}
+
+func (c *apiClient) authenticate(path, login, password string) {
+	c.t.Helper()
+
+	resp, _ := c.do(http.MethodPost, path, "application/json",
+		fmt.Sprintf(`{"login":%q,"password":%q}`, login, password))
+	require.Equal(c.t, http.StatusOK, resp.StatusCode)
+
+	c.token = resp.Header.Get("Authorization")
+	require.NotEmpty(c.t, c.token)
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/pubsub"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newOrderStatusEvent(userID uuid.UUID, number string) business.OrderStatusEvent {
+	return business.OrderStatusEvent{
+		UserID:    userID,
+		Number:    number,
+		Status:    "PROCESSED",
+		Accrual:   100,
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func waitForListener(t *testing.T, publisher *pubsub.PostgreNotifyBridge, broker *pubsub.OrderEventBroker) {
+	t.Helper()
+
+	probeUserID := uuid.New()
+	events, unsubscribe := broker.Subscribe(probeUserID)
+	defer unsubscribe()
+
+	timeout := time.After(10 * time.Second)
+	for {
+		publisher.Publish(context.Background(), newOrderStatusEvent(probeUserID, "probe"))
+		select {
+		case <-events:
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("order status listener did not start")
+		}
+	}
+}
+
+func receiveOrderEvent(t *testing.T, events <-chan business.OrderStatusEvent) business.OrderStatusEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("order status event was not delivered")
+		return business.OrderStatusEvent{}
+	}
+}
+
+func TestPostgreNotifyBridge(t *testing.T) {
+	storage := newTestStorage(t)
+	localBroker := pubsub.NewOrderEventBroker()
+	remoteBroker := pubsub.NewOrderEventBroker()
+	localBridge := pubsub.NewPostgreNotifyBridge(storage, localBroker, zap.NewNop())
+	remoteBridge := pubsub.NewPostgreNotifyBridge(storage, remoteBroker, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go localBridge.Listen(ctx)
+	go remoteBridge.Listen(ctx)
+
+	waitForListener(t, localBridge, remoteBroker)
+	waitForListener(t, remoteBridge, localBroker)
+
+	userID := uuid.New()
+	localEvents, unsubscribeLocal := localBroker.Subscribe(userID)
+	defer unsubscribeLocal()
+	remoteEvents, unsubscribeRemote := remoteBroker.Subscribe(userID)
+	defer unsubscribeRemote()
+
+	localBridge.Publish(ctx, newOrderStatusEvent(userID, "79927398713"))
+	assert.Equal(t, "79927398713", receiveOrderEvent(t, remoteEvents).Number)
+
+	remoteBridge.Publish(ctx, newOrderStatusEvent(userID, "12345678903"))
+	assert.Equal(t, "12345678903", receiveOrderEvent(t, remoteEvents).Number)
+
+	assert.Equal(t, "79927398713", receiveOrderEvent(t, localEvents).Number)
+	require.Equal(t, "12345678903", receiveOrderEvent(t, localEvents).Number,
+		"event published by this instance is not delivered again through NOTIFY")
+	assert.Empty(t, localEvents)
+	assert.Empty(t, remoteEvents)
+}
@@ -0,0 +1,229 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestStorage(t *testing.T) *postgre.PostgreStorage {
+	t.Helper()
+
+	databaseURI := newTestDatabase(t)
+	require.NoError(t, postgre.Migrate(context.Background(), databaseURI, postgre.MigrateUp))
+
+	storage, err := postgre.NewPostgreStorage(context.Background(), zap.NewNop(), databaseURI)
+	require.NoError(t, err)
+	t.Cleanup(storage.Conn.Close)
+
+	return storage
+}
+
+func newUserData(login string) entity.UserData {
+	return entity.UserData{
+		ID:        uuid.New(),
+		Login:     login,
+		Password:  "hash",
+		Role:      entity.UserRoleUser,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestPostgreStorage_WithTx(t *testing.T) {
+	storage := newTestStorage(t)
+	userRepository := repository.NewUserRepository(storage)
+	ctx := context.Background()
+
+	t.Run("commits on success", func(t *testing.T) {
+		userData := newUserData("committed")
+
+		err := storage.WithTx(ctx, func(ctx context.Context) error {
+			return userRepository.Save(ctx, userData)
+		})
+		require.NoError(t, err)
+
+		found, err := userRepository.FindByLogin(ctx, userData.Login)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, userData.ID, found.ID)
+	})
+
+	t.Run("rolls back on error including nested calls", func(t *testing.T) {
+		userData := newUserData("rolled-back")
+		errAbort := errors.New("abort")
+
+		err := storage.WithTx(ctx, func(ctx context.Context) error {
+			nestedErr := storage.WithTx(ctx, func(ctx context.Context) error {
+				return userRepository.Save(ctx, userData)
+			})
+			require.NoError(t, nestedErr)
+
+			found, err := userRepository.FindByLogin(ctx, userData.Login)
+			require.NoError(t, err)
+			require.NotNil(t, found, "row must be visible inside the transaction")
+
+			return errAbort
+		})
+		require.ErrorIs(t, err, errAbort)
+
+		_, err = userRepository.FindByLogin(ctx, userData.Login)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestWithdrawnRepository_GetTotalWithdrawByUser(t *testing.T) {
+	storage := newTestStorage(t)
+	userRepository := repository.NewUserRepository(storage)
+	withdrawRepository := repository.NewWithdrawnRepository(storage)
+	ctx := context.Background()
+
+	userData := newUserData("frank")
+	require.NoError(t, userRepository.Save(ctx, userData))
+
+	var withdrawIDs []uuid.UUID
+	for i, sum := range []float64{100, 40} {
+		withdraw, err := withdrawRepository.Save(ctx, entity.Withdraw{
+			ID:          uuid.New(),
+			OrderNumber: []string{"79927398713", "12345678903"}[i],
+			UserID:      userData.ID,
+			Sum:         sum,
+			Status:      entity.WithdrawStatusCompleted,
+			CreatedAt:   time.Now(),
+		})
+		require.NoError(t, err)
+		withdrawIDs = append(withdrawIDs, withdraw.ID)
+	}
+
+	reversed, err := withdrawRepository.Reverse(ctx, withdrawIDs[1], time.Now())
+	require.NoError(t, err)
+	require.True(t, reversed)
+
+	reversed, err = withdrawRepository.Reverse(ctx, withdrawIDs[1], time.Now())
+	require.NoError(t, err)
+	assert.False(t, reversed, "withdrawal is reversed only once")
+
+	total, err := withdrawRepository.GetTotalWithdrawByUser(ctx, userData.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, total, "reversed withdrawals are not part of the total")
+}
+
+func TestUserRepository_Save(t *testing.T) {
+	storage := newTestStorage(t)
+	userRepository := repository.NewUserRepository(storage)
+	ctx := context.Background()
+
+	require.NoError(t, userRepository.Save(ctx, newUserData("dave")))
+
+	err := userRepository.Save(ctx, newUserData("dave"))
+
+	var appErr *errs.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errs.UserAlreadyExists, appErr.Code)
+}
+
+func TestOrderRepository_UpdateAccrualData(t *testing.T) {
+	storage := newTestStorage(t)
+	userRepository := repository.NewUserRepository(storage)
+	orderRepository := repository.NewOrderRepository(storage)
+	ctx := context.Background()
+
+	userData := newUserData("erin")
+	require.NoError(t, userRepository.Save(ctx, userData))
+
+	_, err := orderRepository.Save(ctx, &entity.Order{
+		ID:        uuid.New(),
+		Number:    "12345678903",
+		Status:    entity.OrderNewStatus,
+		CreatedAt: time.Now(),
+		UserID:    userData.ID,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, orderRepository.UpdateAccrualData(ctx, "12345678903", entity.OrderNewStatus, 0, entity.OrderProcessingStatus, time.Now()))
+
+	var appErr *errs.AppError
+	err = orderRepository.UpdateAccrualData(ctx, "12345678903", entity.OrderNewStatus, 100, entity.OrderProcessedStatus, time.Now())
+	require.ErrorAs(t, err, &appErr, "stale expected status must not match")
+	assert.Equal(t, errs.OrderStatusConflict, appErr.Code)
+
+	require.NoError(t, orderRepository.UpdateAccrualData(ctx, "12345678903", entity.OrderProcessingStatus, 100, entity.OrderProcessedStatus, time.Now()))
+
+	err = orderRepository.UpdateAccrualData(ctx, "12345678903", entity.OrderProcessedStatus, 900, entity.OrderProcessedStatus, time.Now())
+	require.ErrorAs(t, err, &appErr, "final order must not change")
+	assert.Equal(t, errs.OrderStatusConflict, appErr.Code)
+
+	order, err := orderRepository.FindByNumber(ctx, "12345678903")
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderProcessedStatus, order.Status)
+	assert.Equal(t, float64(100), order.Accrual)
+}
+
+func TestMigrate(t *testing.T) {
+	databaseURI := newTestDatabase(t)
+	ctx := context.Background()
+
+	for _, command := range []string{
+		postgre.MigrateUp,
+		postgre.MigrateStatus,
+		postgre.MigrateDown,
+		postgre.MigrateVersion,
+		postgre.MigrateUp,
+	} {
+		require.NoError(t, postgre.Migrate(ctx, databaseURI, command), command)
+	}
+
+	assert.Error(t, postgre.Migrate(ctx, databaseURI, "redo"))
+}
+
+func TestExpirationRepository_GetAccrualLotsByUser(t *testing.T) {
+	storage := newTestStorage(t)
+	userRepository := repository.NewUserRepository(storage)
+	transferRepository := repository.NewTransferRepository(storage)
+	adjustmentRepository := repository.NewBalanceAdjustmentRepository(storage)
+	expirationRepository := repository.NewExpirationRepository(storage)
+	ctx := context.Background()
+
+	sender := newUserData("heidi")
+	recipient := newUserData("ivan")
+	require.NoError(t, userRepository.Save(ctx, sender))
+	require.NoError(t, userRepository.Save(ctx, recipient))
+
+	february := time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC)
+	march := february.AddDate(0, 1, 0)
+
+	_, err := transferRepository.Save(ctx, entity.Transfer{ID: uuid.New(), SenderID: sender.ID, RecipientID: recipient.ID, Sum: 200, CreatedAt: february})
+	require.NoError(t, err)
+	for _, amount := range []float64{50, -30} {
+		_, err = adjustmentRepository.Save(ctx, entity.BalanceAdjustment{
+			ID: uuid.New(), UserID: recipient.ID, Kind: entity.AdjustmentKindManual, Amount: amount, Reason: "test", CreatedAt: march,
+		})
+		require.NoError(t, err)
+	}
+
+	lots, err := expirationRepository.GetAccrualLotsByUser(ctx, recipient.ID)
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	assert.Equal(t, 200.0, lots[0].Amount)
+	assert.True(t, february.Equal(lots[0].CreditedAt))
+	assert.Equal(t, 50.0, lots[1].Amount)
+	assert.True(t, march.Equal(lots[1].CreditedAt))
+
+	lots, err = expirationRepository.GetAccrualLotsByUser(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Empty(t, lots)
+
+	userIDs, err := expirationRepository.GetUsersWithAccrualsCreditedBefore(ctx, february)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{recipient.ID}, userIDs)
+}
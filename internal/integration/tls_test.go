@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func issueCertificate(t *testing.T, commonName string, parent *testCertificate, template *x509.Certificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template.SerialNumber = serial
+	template.Subject = pkix.Name{CommonName: commonName}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCertificate{cert: cert, key: key}
+}
+
+func issueServerCertificate(t *testing.T, commonName string, ca *testCertificate) *testCertificate {
+	return issueCertificate(t, commonName, ca, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	})
+}
+
+func (c *testCertificate) writeFiles(t *testing.T, certFile string, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func TestGophermart_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := issueCertificate(t, "gophermart test CA", nil, &x509.Certificate{
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	})
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0o600))
+	issueServerCertificate(t, "initial", ca).writeFiles(t, certFile, keyFile)
+
+	clientCert := issueCertificate(t, "admin client", ca, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	accrual := newAccrualStub(t)
+	baseURL := startApp(t, "", accrual.URL,
+		"--tls-cert", certFile,
+		"--tls-key", keyFile,
+		"--tls-client-ca", caFile,
+		"--tls-reload-interval", "1",
+	)
+	baseURL = strings.Replace(baseURL, "http://", "https://", 1)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	newHTTPClient := func(certificates ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: rootCAs, Certificates: certificates},
+			ForceAttemptHTTP2: true,
+		}}
+	}
+
+	t.Run("serves HTTP/2 over TLS", func(t *testing.T) {
+		client := &apiClient{t: t, baseURL: baseURL, httpClient: newHTTPClient()}
+
+		resp, _ := client.do(http.MethodGet, "/api/health", "", "")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor)
+	})
+
+	t.Run("requires client certificate on admin routes", func(t *testing.T) {
+		client := &apiClient{t: t, baseURL: baseURL, httpClient: newHTTPClient()}
+		client.authenticate("/api/user/register", "alice", "secret")
+
+		resp, body := client.do(http.MethodGet, "/api/admin/users", "", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, string(body), "Client certificate required")
+
+		resp, _ = client.do(http.MethodGet, "/api/user/balance", "", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		client.httpClient = newHTTPClient(clientCert.tlsCertificate())
+		resp, body = client.do(http.MethodGet, "/api/admin/users", "", "")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.NotContains(t, string(body), "Client certificate required")
+	})
+
+	t.Run("reloads changed certificate", func(t *testing.T) {
+		issueServerCertificate(t, "reloaded", ca).writeFiles(t, certFile, keyFile)
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, future, future))
+
+		address := strings.TrimPrefix(baseURL, "https://")
+		require.Eventually(t, func() bool {
+			conn, err := tls.Dial("tcp", address, &tls.Config{RootCAs: rootCAs})
+			if err != nil {
+				return false
+			}
+			defer conn.Close()
+			return conn.ConnectionState().PeerCertificates[0].Subject.CommonName == "reloaded"
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
@@ -8,14 +8,18 @@ import (
 
 var Log = zap.NewNop()
 
+var atomicLevel = zap.NewAtomicLevel()
+
 func Initialized(level string) error {
 	lvl, err := zap.ParseAtomicLevel(level)
 	if err != nil {
 		return err
 	}
 
+	atomicLevel = lvl
+
 	cfg := zap.NewProductionConfig()
-	cfg.Level = lvl
+	cfg.Level = atomicLevel
 	withCustomTimeLayout("2006-01-02 15:04:05")(&cfg)
 	WithServiceName("GopherMart app")(&cfg)
 
@@ -28,6 +32,16 @@ func Initialized(level string) error {
 	return nil
 }
 
+func SetLevel(name string) error {
+	lvl, err := zapcore.ParseLevel(name)
+	if err != nil {
+		return err
+	}
+
+	atomicLevel.SetLevel(lvl)
+	return nil
+}
+
 type LoggerOption func(*zap.Config)
 
 func withCustomTimeLayout(layout string) LoggerOption {
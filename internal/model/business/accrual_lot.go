@@ -0,0 +1,8 @@
+package business
+
+import "time"
+
+type AccrualLot struct {
+	Amount     float64
+	CreditedAt time.Time
+}
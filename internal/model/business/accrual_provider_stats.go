@@ -0,0 +1,11 @@
+package business
+
+type AccrualProviderStats struct {
+	Name      string
+	Rule      string
+	Circuit   string
+	Polled    int64
+	Processed int64
+	Failed    int64
+	Postponed int64
+}
@@ -1,6 +1,10 @@
 package business
 
 type Balance struct {
-	Total     float64
-	Withdrawn float64
+	Total        float64
+	Withdrawn    float64
+	Held         float64
+	Transferred  float64
+	Adjusted     float64
+	ExpiringSoon float64
 }
@@ -0,0 +1,14 @@
+package business
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OrderStatusEvent struct {
+	UserID    uuid.UUID
+	Number    string
+	Status    string
+	Accrual   float64
+	UpdatedAt time.Time
+}
@@ -0,0 +1,8 @@
+package business
+
+import "github.com/ruslanDantsov/gophermart/internal/model/entity"
+
+type OrderTimeline struct {
+	Order   entity.Order
+	History []entity.OrderStatusHistory
+}
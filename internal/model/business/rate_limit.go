@@ -0,0 +1,17 @@
+package business
+
+import "time"
+
+type RateLimitRule struct {
+	Method   string
+	Path     string
+	Requests int
+	Window   time.Duration
+}
+
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
@@ -0,0 +1,17 @@
+package business
+
+import (
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"time"
+)
+
+type ReconciliationReport struct {
+	RunID      uuid.UUID
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Checked    int
+	Failed     int
+	Corrected  int
+	Mismatches []entity.ReconciliationMismatch
+}
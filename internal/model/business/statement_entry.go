@@ -0,0 +1,22 @@
+package business
+
+import "time"
+
+const (
+	StatementEntryAccrual     = "ACCRUAL"
+	StatementEntryWithdrawal  = "WITHDRAWAL"
+	StatementEntryAdjustment  = "ADJUSTMENT"
+	StatementEntryExpiry      = "EXPIRY"
+	StatementEntryReversal    = "REVERSAL"
+	StatementEntryTransferIn  = "TRANSFER_IN"
+	StatementEntryTransferOut = "TRANSFER_OUT"
+)
+
+type StatementEntry struct {
+	Type        string
+	Amount      float64
+	OrderNumber string
+	Reason      string
+	Reference   string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,16 @@
+package business
+
+import "time"
+
+type TierLevel struct {
+	Name      string
+	Threshold float64
+}
+
+type Profile struct {
+	Tier       string
+	TierSince  *time.Time
+	Accrued    float64
+	NextTier   string
+	ToNextTier float64
+}
@@ -0,0 +1,17 @@
+package business
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveryTask struct {
+	DeliveryID     uuid.UUID
+	Attempts       int
+	URL            string
+	Secret         string
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	EventCreatedAt time.Time
+}
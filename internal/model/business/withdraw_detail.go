@@ -1,9 +1,16 @@
 package business
 
-import "time"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type WithdrawDetail struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
 	OrderNumber string
 	Sum         float64
+	Status      string
 	CreatedAt   time.Time
+	ReversedAt  *time.Time
 }
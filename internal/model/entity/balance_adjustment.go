@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	AdjustmentKindManual         = "MANUAL"
+	AdjustmentKindExpiry         = "EXPIRY"
+	AdjustmentKindReconciliation = "RECONCILIATION"
+)
+
+type BalanceAdjustment struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Kind       string
+	Amount     float64
+	Reason     string
+	OperatorID uuid.UUID
+	Reference  string
+	CreatedAt  time.Time
+}
@@ -8,7 +8,7 @@ import (
 const (
 	OrderNewStatus        = "NEW"
 	OrderProcessingStatus = "PROCESSING"
-	OrderInvalidStatus    = " INVALID"
+	OrderInvalidStatus    = "INVALID"
 	OrderProcessedStatus  = "PROCESSED"
 )
 
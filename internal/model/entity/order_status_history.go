@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OrderStatusHistory struct {
+	ID        uuid.UUID
+	OrderID   uuid.UUID
+	Status    string
+	Accrual   float64
+	ChangedAt time.Time
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	OutboxEventOrderCreated     = "order.created"
+	OutboxEventOrderProcessing  = "order.processing"
+	OutboxEventOrderProcessed   = "order.processed"
+	OutboxEventOrderInvalid     = "order.invalid"
+	OutboxEventWithdrawCreated  = "withdraw.created"
+	OutboxEventWithdrawReversed = "withdraw.reversed"
+	OutboxEventBalanceAdjusted  = "balance.adjusted"
+	OutboxEventPointsExpired    = "points.expired"
+	OutboxEventTransferSent     = "transfer.sent"
+	OutboxEventTransferReceived = "transfer.received"
+	OutboxEventTierChanged      = "tier.changed"
+)
+
+type OutboxEvent struct {
+	ID           uuid.UUID
+	EventType    string
+	UserID       uuid.UUID
+	Payload      []byte
+	CreatedAt    time.Time
+	DispatchedAt *time.Time
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ReconciliationMismatch struct {
+	ID            uuid.UUID
+	RunID         uuid.UUID
+	OrderID       uuid.UUID
+	OrderNumber   string
+	UserID        uuid.UUID
+	StoredStatus  string
+	StoredAccrual float64
+	RemoteStatus  string
+	RemoteAccrual float64
+	Difference    float64
+	Corrected     bool
+	DetectedAt    time.Time
+}
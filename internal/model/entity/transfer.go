@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Transfer struct {
+	ID          uuid.UUID
+	SenderID    uuid.UUID
+	RecipientID uuid.UUID
+	Sum         float64
+	CreatedAt   time.Time
+}
@@ -5,9 +5,15 @@ import (
 	"time"
 )
 
+const (
+	UserRoleUser  = "USER"
+	UserRoleAdmin = "ADMIN"
+)
+
 type UserData struct {
 	ID        uuid.UUID
 	Login     string
 	Password  string
+	Role      string
 	CreatedAt time.Time
 }
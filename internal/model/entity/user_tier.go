@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TierBronze = "BRONZE"
+	TierSilver = "SILVER"
+	TierGold   = "GOLD"
+)
+
+type UserTier struct {
+	UserID    uuid.UUID
+	Tier      string
+	Accrued   float64
+	TierSince time.Time
+	UpdatedAt time.Time
+}
+
+type UserTierHistory struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	PreviousTier string
+	Tier         string
+	Accrued      float64
+	ChangedAt    time.Time
+}
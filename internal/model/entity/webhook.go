@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Webhook struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -5,9 +5,17 @@ import (
 	"time"
 )
 
+const (
+	WithdrawStatusCompleted = "COMPLETED"
+	WithdrawStatusReversed  = "REVERSED"
+)
+
 type Withdraw struct {
-	ID        uuid.UUID
-	Sum       float64
-	CreatedAt time.Time
-	OrderID   uuid.UUID
+	ID          uuid.UUID
+	OrderNumber string
+	UserID      uuid.UUID
+	Sum         float64
+	Status      string
+	CreatedAt   time.Time
+	ReversedAt  *time.Time
 }
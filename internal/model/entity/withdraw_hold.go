@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	WithdrawHoldStatusActive   = "ACTIVE"
+	WithdrawHoldStatusCaptured = "CAPTURED"
+	WithdrawHoldStatusReleased = "RELEASED"
+	WithdrawHoldStatusExpired  = "EXPIRED"
+)
+
+type WithdrawHold struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	OrderNumber string
+	Sum         float64
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	ResolvedAt  *time.Time
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+)
+
+type BalanceAdjustmentRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewBalanceAdjustmentRepository(storage *postgre.PostgreStorage) *BalanceAdjustmentRepository {
+	return &BalanceAdjustmentRepository{storage: storage}
+}
+
+func (r *BalanceAdjustmentRepository) Save(ctx context.Context, adjustment entity.BalanceAdjustment) (*entity.BalanceAdjustment, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var operatorID any
+	if adjustment.OperatorID != uuid.Nil {
+		operatorID = adjustment.OperatorID
+	}
+
+	_, err := db.Exec(ctx,
+		query.InsertBalanceAdjustment,
+		adjustment.ID,
+		adjustment.UserID,
+		adjustment.Kind,
+		adjustment.Amount,
+		adjustment.Reason,
+		operatorID,
+		adjustment.Reference,
+		adjustment.CreatedAt)
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &adjustment, nil
+}
+
+func (r *BalanceAdjustmentRepository) GetTotalAdjustmentByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var totalAdjustment float64
+	err := db.QueryRow(ctx,
+		query.GetTotalAdjustmentByUser,
+		userID).
+		Scan(&totalAdjustment)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return totalAdjustment, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type ExpirationRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewExpirationRepository(storage *postgre.PostgreStorage) *ExpirationRepository {
+	return &ExpirationRepository{storage: storage}
+}
+
+func (r *ExpirationRepository) GetUsersWithAccrualsCreditedBefore(ctx context.Context, creditedBefore time.Time) ([]uuid.UUID, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	rows, err := db.Query(ctx, query.GetUsersWithAccrualsCreditedBefore, creditedBefore)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan row ", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *ExpirationRepository) GetAccrualLotsByUser(ctx context.Context, userID uuid.UUID) ([]business.AccrualLot, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	rows, err := db.Query(ctx, query.GetAccrualLotsByUser, userID)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	var lots []business.AccrualLot
+	for rows.Next() {
+		var lot business.AccrualLot
+		if err := rows.Scan(&lot.Amount, &lot.CreditedAt); err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan row ", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return lots, nil
+}
+
+func (r *ExpirationRepository) GetTotalDebitByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var totalDebit float64
+	err := db.QueryRow(ctx,
+		query.GetTotalDebitByUser,
+		userID).
+		Scan(&totalDebit)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return totalDebit, nil
+}
@@ -0,0 +1,45 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+)
+
+type BalanceAdjustmentRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewBalanceAdjustmentRepository(storage *memory.MemoryStorage) *BalanceAdjustmentRepository {
+	return &BalanceAdjustmentRepository{
+		storage: storage,
+	}
+}
+
+func (r *BalanceAdjustmentRepository) Save(ctx context.Context, adjustment entity.BalanceAdjustment) (*entity.BalanceAdjustment, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.BalanceAdjustments = append(tables.BalanceAdjustments, adjustment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &adjustment, nil
+}
+
+func (r *BalanceAdjustmentRepository) GetTotalAdjustmentByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.UserID == userID {
+				total += adjustment.Amount
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
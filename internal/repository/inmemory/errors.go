@@ -0,0 +1,5 @@
+package inmemory
+
+import "errors"
+
+var errUniqueViolation = errors.New("duplicate key value violates unique constraint")
@@ -0,0 +1,117 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type ExpirationRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewExpirationRepository(storage *memory.MemoryStorage) *ExpirationRepository {
+	return &ExpirationRepository{
+		storage: storage,
+	}
+}
+
+func isCreditedAccrual(record memory.OrderRecord) bool {
+	return record.Status == entity.OrderProcessedStatus && record.Accrual > 0 && record.ProcessedAt != nil
+}
+
+func (r *ExpirationRepository) GetUsersWithAccrualsCreditedBefore(ctx context.Context, creditedBefore time.Time) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		seen := make(map[uuid.UUID]struct{})
+		add := func(userID uuid.UUID, creditedAt time.Time) {
+			if _, ok := seen[userID]; ok || creditedAt.After(creditedBefore) {
+				return
+			}
+
+			seen[userID] = struct{}{}
+			userIDs = append(userIDs, userID)
+		}
+
+		for _, record := range tables.Orders {
+			if isCreditedAccrual(record) {
+				add(record.UserID, *record.ProcessedAt)
+			}
+		}
+		for _, transfer := range tables.Transfers {
+			add(transfer.RecipientID, transfer.CreatedAt)
+		}
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.Amount > 0 {
+				add(adjustment.UserID, adjustment.CreatedAt)
+			}
+		}
+		return nil
+	})
+
+	return userIDs, err
+}
+
+func (r *ExpirationRepository) GetAccrualLotsByUser(ctx context.Context, userID uuid.UUID) ([]business.AccrualLot, error) {
+	var lots []business.AccrualLot
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.UserID == userID && isCreditedAccrual(record) {
+				lots = append(lots, business.AccrualLot{Amount: record.Accrual, CreditedAt: *record.ProcessedAt})
+			}
+		}
+		for _, transfer := range tables.Transfers {
+			if transfer.RecipientID == userID {
+				lots = append(lots, business.AccrualLot{Amount: transfer.Sum, CreditedAt: transfer.CreatedAt})
+			}
+		}
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.UserID == userID && adjustment.Amount > 0 {
+				lots = append(lots, business.AccrualLot{Amount: adjustment.Amount, CreditedAt: adjustment.CreatedAt})
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(lots, func(i, j int) bool {
+		return lots[i].CreditedAt.Before(lots[j].CreditedAt)
+	})
+
+	return lots, err
+}
+
+func (r *ExpirationRepository) GetTotalDebitByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, withdraw := range tables.Withdraws {
+			if withdraw.UserID == userID && withdraw.Status == entity.WithdrawStatusCompleted {
+				total += withdraw.Sum
+			}
+		}
+
+		total += totalHeld(tables, userID)
+
+		for _, transfer := range tables.Transfers {
+			if transfer.SenderID == userID {
+				total += transfer.Sum
+			}
+		}
+
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.UserID == userID && adjustment.Amount < 0 {
+				total -= adjustment.Amount
+			}
+		}
+
+		return nil
+	})
+
+	return total, err
+}
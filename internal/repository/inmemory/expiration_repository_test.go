@@ -0,0 +1,61 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpirationRepository_AccrualLots(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewMemoryStorage()
+	repository := NewExpirationRepository(storage)
+
+	accrualUserID := uuid.New()
+	recipientID := uuid.New()
+	january := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	february := january.AddDate(0, 1, 0)
+	march := january.AddDate(0, 2, 0)
+
+	require.NoError(t, storage.Do(ctx, func(tables *memory.Tables) error {
+		orderID := uuid.New()
+		tables.Orders[orderID] = memory.OrderRecord{
+			Order:       entity.Order{ID: orderID, UserID: accrualUserID, Status: entity.OrderProcessedStatus, Accrual: 500},
+			ProcessedAt: &january,
+		}
+		tables.Transfers = append(tables.Transfers, entity.Transfer{
+			ID: uuid.New(), SenderID: accrualUserID, RecipientID: recipientID, Sum: 200, CreatedAt: february,
+		})
+		tables.BalanceAdjustments = append(tables.BalanceAdjustments,
+			entity.BalanceAdjustment{ID: uuid.New(), UserID: recipientID, Kind: entity.AdjustmentKindManual, Amount: 50, CreatedAt: march},
+			entity.BalanceAdjustment{ID: uuid.New(), UserID: recipientID, Kind: entity.AdjustmentKindManual, Amount: -30, CreatedAt: march},
+		)
+		return nil
+	}))
+
+	lots, err := repository.GetAccrualLotsByUser(ctx, recipientID)
+	require.NoError(t, err)
+	assert.Equal(t, []business.AccrualLot{
+		{Amount: 200, CreditedAt: february},
+		{Amount: 50, CreditedAt: march},
+	}, lots, "received transfers and positive adjustments are lots, sent transfers and negative adjustments are not")
+
+	lots, err = repository.GetAccrualLotsByUser(ctx, accrualUserID)
+	require.NoError(t, err)
+	assert.Equal(t, []business.AccrualLot{{Amount: 500, CreditedAt: january}}, lots)
+
+	userIDs, err := repository.GetUsersWithAccrualsCreditedBefore(ctx, february)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{accrualUserID, recipientID}, userIDs)
+
+	userIDs, err = repository.GetUsersWithAccrualsCreditedBefore(ctx, january)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{accrualUserID}, userIDs)
+}
@@ -0,0 +1,146 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type OrderRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewOrderRepository(storage *memory.MemoryStorage) *OrderRepository {
+	return &OrderRepository{
+		storage: storage,
+	}
+}
+
+func findOrderByNumber(tables *memory.Tables, number string) (memory.OrderRecord, bool) {
+	for _, record := range tables.Orders {
+		if record.Number == number {
+			return record, true
+		}
+	}
+	return memory.OrderRecord{}, false
+}
+
+func (r *OrderRepository) FindUserIDByOrderNumber(ctx context.Context, orderNumber string) (uuid.UUID, error) {
+	userID := uuid.Nil
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if record, ok := findOrderByNumber(tables, orderNumber); ok {
+			userID = record.UserID
+		}
+		return nil
+	})
+
+	return userID, err
+}
+
+func (r *OrderRepository) FindByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	return r.GetByNumber(ctx, orderNumber)
+}
+
+func (r *OrderRepository) GetByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	var order *entity.Order
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if record, ok := findOrderByNumber(tables, orderNumber); ok {
+			order = &record.Order
+		}
+		return nil
+	})
+
+	return order, err
+}
+
+func (r *OrderRepository) Save(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if _, exists := findOrderByNumber(tables, order.Number); exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+		if _, exists := tables.Orders[order.ID]; exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+
+		tables.Orders[order.ID] = memory.OrderRecord{Order: *order}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *OrderRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Order, error) {
+	var orders []entity.Order
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.UserID == userID {
+				orders = append(orders, record.Order)
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.After(orders[j].CreatedAt)
+	})
+
+	return orders, err
+}
+
+func (r *OrderRepository) GetUnprocessedOrders(ctx context.Context) ([]string, error) {
+	var numbers []string
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.Status == entity.OrderNewStatus || record.Status == entity.OrderProcessingStatus {
+				numbers = append(numbers, record.Number)
+			}
+		}
+		return nil
+	})
+
+	return numbers, err
+}
+
+func (r *OrderRepository) GetTotalAccrualByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.UserID == userID && record.Status == entity.OrderProcessedStatus {
+				total += record.Accrual
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
+
+func (r *OrderRepository) UpdateAccrualData(ctx context.Context, number string, fromStatus string, accrual float64, status string, changedAt time.Time) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		record, ok := findOrderByNumber(tables, number)
+		if !ok || record.Status != fromStatus ||
+			(record.Status != entity.OrderNewStatus && record.Status != entity.OrderProcessingStatus) {
+			return errs.New(errs.OrderStatusConflict, "order status has been changed concurrently or is final", nil)
+		}
+
+		record.Status = status
+		record.Accrual = accrual
+		if status == entity.OrderProcessedStatus {
+			record.ProcessedAt = &changedAt
+		}
+		tables.Orders[record.ID] = record
+		return nil
+	})
+}
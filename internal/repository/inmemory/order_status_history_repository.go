@@ -0,0 +1,45 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+)
+
+type OrderStatusHistoryRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewOrderStatusHistoryRepository(storage *memory.MemoryStorage) *OrderStatusHistoryRepository {
+	return &OrderStatusHistoryRepository{
+		storage: storage,
+	}
+}
+
+func (r *OrderStatusHistoryRepository) Save(ctx context.Context, history entity.OrderStatusHistory) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.OrderStatusHistory = append(tables.OrderStatusHistory, history)
+		return nil
+	})
+}
+
+func (r *OrderStatusHistoryRepository) GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error) {
+	var history []entity.OrderStatusHistory
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, entry := range tables.OrderStatusHistory {
+			if entry.OrderID == orderID {
+				history = append(history, entry)
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].ChangedAt.Before(history[j].ChangedAt)
+	})
+
+	return history, err
+}
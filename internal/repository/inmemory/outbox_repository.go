@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type OutboxRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewOutboxRepository(storage *memory.MemoryStorage) *OutboxRepository {
+	return &OutboxRepository{
+		storage: storage,
+	}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, event entity.OutboxEvent) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if _, exists := tables.OutboxEvents[event.ID]; exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+
+		event.DispatchedAt = nil
+		tables.OutboxEvents[event.ID] = event
+		return nil
+	})
+}
+
+func (r *OutboxRepository) GetUndispatched(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	var events []entity.OutboxEvent
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, event := range tables.OutboxEvents {
+			if event.DispatchedAt == nil {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, err
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		event, ok := tables.OutboxEvents[id]
+		if !ok {
+			return nil
+		}
+
+		event.DispatchedAt = &dispatchedAt
+		tables.OutboxEvents[id] = event
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type rateLimitCounter struct {
+	windowStart time.Time
+	hits        int
+	expiresAt   time.Time
+}
+
+type RateLimitRepository struct {
+	mu       sync.Mutex
+	counters map[string]*rateLimitCounter
+}
+
+func NewRateLimitRepository() *RateLimitRepository {
+	return &RateLimitRepository{
+		counters: make(map[string]*rateLimitCounter),
+	}
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, windowStart time.Time, expiresAt time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counter, ok := r.counters[key]
+	if !ok || !counter.windowStart.Equal(windowStart) {
+		counter = &rateLimitCounter{windowStart: windowStart, expiresAt: expiresAt}
+		r.counters[key] = counter
+	}
+
+	counter.hits++
+	return counter.hits, nil
+}
+
+func (r *RateLimitRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, counter := range r.counters {
+		if !counter.expiresAt.After(now) {
+			delete(r.counters, key)
+		}
+	}
+	return nil
+}
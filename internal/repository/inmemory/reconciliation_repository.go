@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"math/rand/v2"
+	"sort"
+)
+
+type ReconciliationRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewReconciliationRepository(storage *memory.MemoryStorage) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		storage: storage,
+	}
+}
+
+func (r *ReconciliationRepository) GetFinalizedOrders(ctx context.Context, sampleSize int) ([]entity.Order, error) {
+	var orders []entity.Order
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.Status == entity.OrderProcessedStatus || record.Status == entity.OrderInvalidStatus {
+				orders = append(orders, record.Order)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sampleSize > 0 {
+		rand.Shuffle(len(orders), func(i, j int) {
+			orders[i], orders[j] = orders[j], orders[i]
+		})
+		return orders[:min(sampleSize, len(orders))], nil
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return orders, nil
+}
+
+func (r *ReconciliationRepository) GetCorrectionByOrder(ctx context.Context, orderNumber string) (float64, error) {
+	var correction float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.Kind == entity.AdjustmentKindReconciliation && adjustment.Reference == orderNumber {
+				correction += adjustment.Amount
+			}
+		}
+		return nil
+	})
+
+	return correction, err
+}
+
+func (r *ReconciliationRepository) SaveMismatch(ctx context.Context, mismatch entity.ReconciliationMismatch) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.ReconciliationMismatches = append(tables.ReconciliationMismatches, mismatch)
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+)
+
+type StatementRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewStatementRepository(storage *memory.MemoryStorage) *StatementRepository {
+	return &StatementRepository{
+		storage: storage,
+	}
+}
+
+func (r *StatementRepository) GetStatementByUser(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error) {
+	var entries []business.StatementEntry
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.UserID == userID && record.Status == entity.OrderProcessedStatus {
+				creditedAt := record.CreatedAt
+				if record.ProcessedAt != nil {
+					creditedAt = *record.ProcessedAt
+				}
+				entries = append(entries, business.StatementEntry{
+					Type:        business.StatementEntryAccrual,
+					Amount:      record.Accrual,
+					OrderNumber: record.Number,
+					CreatedAt:   creditedAt,
+				})
+			}
+		}
+
+		for _, withdraw := range tables.Withdraws {
+			if withdraw.UserID != userID {
+				continue
+			}
+
+			entries = append(entries, business.StatementEntry{
+				Type:        business.StatementEntryWithdrawal,
+				Amount:      -withdraw.Sum,
+				OrderNumber: withdraw.OrderNumber,
+				CreatedAt:   withdraw.CreatedAt,
+			})
+
+			if withdraw.Status == entity.WithdrawStatusReversed && withdraw.ReversedAt != nil {
+				entries = append(entries, business.StatementEntry{
+					Type:        business.StatementEntryReversal,
+					Amount:      withdraw.Sum,
+					OrderNumber: withdraw.OrderNumber,
+					CreatedAt:   *withdraw.ReversedAt,
+				})
+			}
+		}
+
+		for _, transfer := range tables.Transfers {
+			if transfer.SenderID == userID {
+				entries = append(entries, business.StatementEntry{
+					Type:      business.StatementEntryTransferOut,
+					Amount:    -transfer.Sum,
+					Reference: tables.Users[transfer.RecipientID].Login,
+					CreatedAt: transfer.CreatedAt,
+				})
+			}
+			if transfer.RecipientID == userID {
+				entries = append(entries, business.StatementEntry{
+					Type:      business.StatementEntryTransferIn,
+					Amount:    transfer.Sum,
+					Reference: tables.Users[transfer.SenderID].Login,
+					CreatedAt: transfer.CreatedAt,
+				})
+			}
+		}
+
+		for _, adjustment := range tables.BalanceAdjustments {
+			if adjustment.UserID != userID {
+				continue
+			}
+
+			entryType := business.StatementEntryAdjustment
+			if adjustment.Kind == entity.AdjustmentKindExpiry {
+				entryType = business.StatementEntryExpiry
+			}
+
+			entries = append(entries, business.StatementEntry{
+				Type:      entryType,
+				Amount:    adjustment.Amount,
+				Reason:    adjustment.Reason,
+				Reference: adjustment.Reference,
+				CreatedAt: adjustment.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	return entries, err
+}
@@ -0,0 +1,49 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementRepository_GetStatementByUser(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.NewMemoryStorage()
+	repository := NewStatementRepository(storage)
+
+	userID := uuid.New()
+	uploadedAt := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	processedAt := uploadedAt.AddDate(0, 0, 3)
+
+	require.NoError(t, storage.Do(ctx, func(tables *memory.Tables) error {
+		orderID := uuid.New()
+		tables.Orders[orderID] = memory.OrderRecord{
+			Order: entity.Order{
+				ID:        orderID,
+				Number:    "79927398713",
+				UserID:    userID,
+				Status:    entity.OrderProcessedStatus,
+				Accrual:   500,
+				CreatedAt: uploadedAt,
+			},
+			ProcessedAt: &processedAt,
+		}
+		return nil
+	}))
+
+	entries, err := repository.GetStatementByUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []business.StatementEntry{{
+		Type:        business.StatementEntryAccrual,
+		Amount:      500,
+		OrderNumber: "79927398713",
+		CreatedAt:   processedAt,
+	}}, entries, "accruals are dated when the order was processed, not uploaded")
+}
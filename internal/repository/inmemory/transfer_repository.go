@@ -0,0 +1,64 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"time"
+)
+
+type TransferRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewTransferRepository(storage *memory.MemoryStorage) *TransferRepository {
+	return &TransferRepository{
+		storage: storage,
+	}
+}
+
+func (r *TransferRepository) Save(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.Transfers = append(tables.Transfers, transfer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+func (r *TransferRepository) GetNetTransferByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var net float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, transfer := range tables.Transfers {
+			if transfer.RecipientID == userID {
+				net += transfer.Sum
+			}
+			if transfer.SenderID == userID {
+				net -= transfer.Sum
+			}
+		}
+		return nil
+	})
+
+	return net, err
+}
+
+func (r *TransferRepository) GetTotalSentByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, transfer := range tables.Transfers {
+			if transfer.SenderID == userID && !transfer.CreatedAt.Before(since) {
+				total += transfer.Sum
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
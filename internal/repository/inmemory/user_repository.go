@@ -0,0 +1,157 @@
+package inmemory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+)
+
+type UserRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewUserRepository(storage *memory.MemoryStorage) *UserRepository {
+	return &UserRepository{
+		storage: storage,
+	}
+}
+
+func findUserByLogin(tables *memory.Tables, login string) (entity.UserData, bool) {
+	for _, userData := range tables.Users {
+		if userData.Login == login {
+			return userData, true
+		}
+	}
+	return entity.UserData{}, false
+}
+
+func (r *UserRepository) Save(ctx context.Context, userData entity.UserData) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if existing, ok := findUserByLogin(tables, userData.Login); ok && existing.ID != userData.ID {
+			return errs.New(errs.UserAlreadyExists, "login not unique ", errUniqueViolation)
+		}
+
+		if existing, ok := tables.Users[userData.ID]; ok {
+			existing.Login = userData.Login
+			existing.Password = userData.Password
+			tables.Users[userData.ID] = existing
+			return nil
+		}
+
+		tables.Users[userData.ID] = userData
+		return nil
+	})
+}
+
+func (r *UserRepository) FindByLogin(ctx context.Context, login string) (*entity.UserData, error) {
+	var userData *entity.UserData
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		existing, ok := findUserByLogin(tables, login)
+		if !ok {
+			return fmt.Errorf("error on on searching user data: %w", sql.ErrNoRows)
+		}
+		userData = &existing
+		return nil
+	})
+
+	return userData, err
+}
+
+func (r *UserRepository) GetAll(ctx context.Context) ([]entity.UserData, error) {
+	var users []entity.UserData
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, userData := range tables.Users {
+			users = append(users, entity.UserData{
+				ID:        userData.ID,
+				Login:     userData.Login,
+				Role:      userData.Role,
+				CreatedAt: userData.CreatedAt,
+			})
+		}
+		return nil
+	})
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+
+	return users, err
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) (bool, error) {
+	updated := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		userData, ok := tables.Users[id]
+		if !ok {
+			return nil
+		}
+
+		userData.Role = role
+		tables.Users[id] = userData
+		updated = true
+		return nil
+	})
+
+	return updated, err
+}
+
+func (r *UserRepository) UpdateRoleByLogin(ctx context.Context, login string, role string) (bool, error) {
+	updated := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		userData, ok := findUserByLogin(tables, login)
+		if !ok {
+			return nil
+		}
+
+		userData.Role = role
+		tables.Users[userData.ID] = userData
+		updated = true
+		return nil
+	})
+
+	return updated, err
+}
+
+func (r *UserRepository) FindIDByLogin(ctx context.Context, login string) (uuid.UUID, error) {
+	id := uuid.Nil
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if userData, ok := findUserByLogin(tables, login); ok {
+			id = userData.ID
+		}
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *UserRepository) FindRoleByID(ctx context.Context, id uuid.UUID) (string, error) {
+	var role string
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		role = tables.Users[id].Role
+		return nil
+	})
+
+	return role, err
+}
+
+func (r *UserRepository) LockByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	found := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		_, found = tables.Users[id]
+		return nil
+	})
+
+	return found, err
+}
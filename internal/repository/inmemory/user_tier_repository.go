@@ -0,0 +1,77 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"time"
+)
+
+type UserTierRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewUserTierRepository(storage *memory.MemoryStorage) *UserTierRepository {
+	return &UserTierRepository{
+		storage: storage,
+	}
+}
+
+func (r *UserTierRepository) GetAccruedByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, record := range tables.Orders {
+			if record.UserID != userID || record.Status != entity.OrderProcessedStatus || record.ProcessedAt == nil {
+				continue
+			}
+			if !record.ProcessedAt.Before(since) {
+				total += record.Accrual
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
+
+func (r *UserTierRepository) FindByUser(ctx context.Context, userID uuid.UUID) (*entity.UserTier, error) {
+	var userTier *entity.UserTier
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if found, ok := tables.UserTiers[userID]; ok {
+			userTier = &found
+		}
+		return nil
+	})
+
+	return userTier, err
+}
+
+func (r *UserTierRepository) GetAll(ctx context.Context) ([]entity.UserTier, error) {
+	var userTiers []entity.UserTier
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, userTier := range tables.UserTiers {
+			userTiers = append(userTiers, userTier)
+		}
+		return nil
+	})
+
+	return userTiers, err
+}
+
+func (r *UserTierRepository) Save(ctx context.Context, userTier entity.UserTier) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.UserTiers[userTier.UserID] = userTier
+		return nil
+	})
+}
+
+func (r *UserTierRepository) SaveHistory(ctx context.Context, history entity.UserTierHistory) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		tables.UserTierHistory = append(tables.UserTierHistory, history)
+		return nil
+	})
+}
@@ -0,0 +1,99 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type WebhookDeliveryRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewWebhookDeliveryRepository(storage *memory.MemoryStorage) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		storage: storage,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery entity.WebhookDelivery) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if _, exists := tables.WebhookDeliveries[delivery.ID]; exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+
+		tables.WebhookDeliveries[delivery.ID] = delivery
+		return nil
+	})
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]business.WebhookDeliveryTask, error) {
+	var tasks []business.WebhookDeliveryTask
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		var due []entity.WebhookDelivery
+		for _, delivery := range tables.WebhookDeliveries {
+			if delivery.Status == entity.WebhookDeliveryPendingStatus && !delivery.NextAttemptAt.After(now) {
+				due = append(due, delivery)
+			}
+		}
+
+		sort.SliceStable(due, func(i, j int) bool {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		})
+
+		if len(due) > limit {
+			due = due[:limit]
+		}
+
+		for _, delivery := range due {
+			webhook, ok := tables.Webhooks[delivery.WebhookID]
+			if !ok {
+				continue
+			}
+			event, ok := tables.OutboxEvents[delivery.EventID]
+			if !ok {
+				continue
+			}
+
+			delivery.NextAttemptAt = leaseUntil
+			tables.WebhookDeliveries[delivery.ID] = delivery
+
+			tasks = append(tasks, business.WebhookDeliveryTask{
+				DeliveryID:     delivery.ID,
+				Attempts:       delivery.Attempts,
+				URL:            webhook.URL,
+				Secret:         webhook.Secret,
+				EventID:        event.ID,
+				EventType:      event.EventType,
+				Payload:        event.Payload,
+				EventCreatedAt: event.CreatedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return tasks, err
+}
+
+func (r *WebhookDeliveryRepository) UpdateResult(ctx context.Context, delivery entity.WebhookDelivery) error {
+	return r.storage.Do(ctx, func(tables *memory.Tables) error {
+		existing, ok := tables.WebhookDeliveries[delivery.ID]
+		if !ok {
+			return nil
+		}
+
+		existing.Status = delivery.Status
+		existing.Attempts = delivery.Attempts
+		existing.NextAttemptAt = delivery.NextAttemptAt
+		existing.LastError = delivery.LastError
+		existing.DeliveredAt = delivery.DeliveredAt
+		tables.WebhookDeliveries[delivery.ID] = existing
+		return nil
+	})
+}
@@ -0,0 +1,110 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type WebhookRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewWebhookRepository(storage *memory.MemoryStorage) *WebhookRepository {
+	return &WebhookRepository{
+		storage: storage,
+	}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if _, exists := tables.Webhooks[webhook.ID]; exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+
+		tables.Webhooks[webhook.ID] = webhook
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) UpdateURL(ctx context.Context, id uuid.UUID, userID uuid.UUID, url string, updatedAt time.Time) (bool, error) {
+	updated := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		webhook, ok := tables.Webhooks[id]
+		if !ok || webhook.UserID != userID {
+			return nil
+		}
+
+		webhook.URL = url
+		webhook.UpdatedAt = updatedAt
+		tables.Webhooks[id] = webhook
+		updated = true
+		return nil
+	})
+
+	return updated, err
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
+	deleted := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		webhook, ok := tables.Webhooks[id]
+		if !ok || webhook.UserID != userID {
+			return nil
+		}
+
+		delete(tables.Webhooks, id)
+		for deliveryID, delivery := range tables.WebhookDeliveries {
+			if delivery.WebhookID == id {
+				delete(tables.WebhookDeliveries, deliveryID)
+			}
+		}
+		deleted = true
+		return nil
+	})
+
+	return deleted, err
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Webhook, error) {
+	var webhook *entity.Webhook
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if found, ok := tables.Webhooks[id]; ok && found.UserID == userID {
+			webhook = &found
+		}
+		return nil
+	})
+
+	return webhook, err
+}
+
+func (r *WebhookRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, webhook := range tables.Webhooks {
+			if webhook.UserID == userID {
+				webhooks = append(webhooks, webhook)
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.After(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, err
+}
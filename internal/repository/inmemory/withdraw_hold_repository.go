@@ -0,0 +1,141 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type WithdrawHoldRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewWithdrawHoldRepository(storage *memory.MemoryStorage) *WithdrawHoldRepository {
+	return &WithdrawHoldRepository{
+		storage: storage,
+	}
+}
+
+func findActiveHold(tables *memory.Tables, orderNumber string) (entity.WithdrawHold, bool) {
+	for _, hold := range tables.WithdrawHolds {
+		if hold.OrderNumber == orderNumber && hold.Status == entity.WithdrawHoldStatusActive {
+			return hold, true
+		}
+	}
+	return entity.WithdrawHold{}, false
+}
+
+func (r *WithdrawHoldRepository) Save(ctx context.Context, hold entity.WithdrawHold) (*entity.WithdrawHold, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if _, exists := tables.WithdrawHolds[hold.ID]; exists {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+		if _, exists := findActiveHold(tables, hold.OrderNumber); exists && hold.Status == entity.WithdrawHoldStatusActive {
+			return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+		}
+
+		tables.WithdrawHolds[hold.ID] = hold
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+func (r *WithdrawHoldRepository) FindActive(ctx context.Context, orderNumber string) (*entity.WithdrawHold, error) {
+	var hold *entity.WithdrawHold
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		if found, ok := findActiveHold(tables, orderNumber); ok {
+			hold = &found
+		}
+		return nil
+	})
+
+	return hold, err
+}
+
+func (r *WithdrawHoldRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error) {
+	var holds []entity.WithdrawHold
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, hold := range tables.WithdrawHolds {
+			if hold.UserID == userID && hold.Status == entity.WithdrawHoldStatusActive {
+				holds = append(holds, hold)
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(holds, func(i, j int) bool {
+		return holds[i].CreatedAt.After(holds[j].CreatedAt)
+	})
+
+	return holds, err
+}
+
+func (r *WithdrawHoldRepository) Resolve(ctx context.Context, id uuid.UUID, status string, resolvedAt time.Time) (bool, error) {
+	resolved := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		hold, ok := tables.WithdrawHolds[id]
+		if !ok || hold.Status != entity.WithdrawHoldStatusActive {
+			return nil
+		}
+
+		hold.Status = status
+		hold.ResolvedAt = &resolvedAt
+		tables.WithdrawHolds[id] = hold
+		resolved = true
+		return nil
+	})
+
+	return resolved, err
+}
+
+func (r *WithdrawHoldRepository) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	var expired int64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for id, hold := range tables.WithdrawHolds {
+			if hold.Status != entity.WithdrawHoldStatusActive || hold.ExpiresAt.After(now) {
+				continue
+			}
+
+			hold.Status = entity.WithdrawHoldStatusExpired
+			hold.ResolvedAt = &now
+			tables.WithdrawHolds[id] = hold
+			expired++
+		}
+		return nil
+	})
+
+	return expired, err
+}
+
+func (r *WithdrawHoldRepository) GetTotalHeldByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		total = totalHeld(tables, userID)
+		return nil
+	})
+
+	return total, err
+}
+
+func totalHeld(tables *memory.Tables, userID uuid.UUID) float64 {
+	var total float64
+	for _, hold := range tables.WithdrawHolds {
+		if hold.UserID == userID && hold.Status == entity.WithdrawHoldStatusActive {
+			total += hold.Sum
+		}
+	}
+	return total
+}
@@ -0,0 +1,122 @@
+package inmemory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/memory"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"sort"
+	"time"
+)
+
+type WithdrawnRepository struct {
+	storage *memory.MemoryStorage
+}
+
+func NewWithdrawnRepository(storage *memory.MemoryStorage) *WithdrawnRepository {
+	return &WithdrawnRepository{
+		storage: storage,
+	}
+}
+
+func toWithdrawDetail(withdraw entity.Withdraw) business.WithdrawDetail {
+	return business.WithdrawDetail{
+		ID:          withdraw.ID,
+		UserID:      withdraw.UserID,
+		OrderNumber: withdraw.OrderNumber,
+		Sum:         withdraw.Sum,
+		Status:      withdraw.Status,
+		CreatedAt:   withdraw.CreatedAt,
+		ReversedAt:  withdraw.ReversedAt,
+	}
+}
+
+func (r *WithdrawnRepository) GetTotalWithdrawByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, withdraw := range tables.Withdraws {
+			if withdraw.UserID == userID && withdraw.Status == entity.WithdrawStatusCompleted {
+				total += withdraw.Sum
+			}
+		}
+		return nil
+	})
+
+	return total, err
+}
+
+func (r *WithdrawnRepository) Save(ctx context.Context, withdraw entity.Withdraw) (*entity.Withdraw, error) {
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, existing := range tables.Withdraws {
+			if existing.ID == withdraw.ID || existing.OrderNumber == withdraw.OrderNumber {
+				return errs.New(errs.Generic, "failed to execute query ", errUniqueViolation)
+			}
+		}
+
+		tables.Withdraws[withdraw.ID] = withdraw
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &withdraw, nil
+}
+
+func (r *WithdrawnRepository) GetAllWithdrawDetailsByUser(ctx context.Context, userID uuid.UUID) ([]business.WithdrawDetail, error) {
+	var details []business.WithdrawDetail
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, withdraw := range tables.Withdraws {
+			if withdraw.UserID == userID {
+				details = append(details, toWithdrawDetail(withdraw))
+			}
+		}
+		return nil
+	})
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].CreatedAt.After(details[j].CreatedAt)
+	})
+
+	return details, err
+}
+
+func (r *WithdrawnRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error) {
+	var detail *business.WithdrawDetail
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		for _, withdraw := range tables.Withdraws {
+			if withdraw.OrderNumber == orderNumber {
+				found := toWithdrawDetail(withdraw)
+				detail = &found
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return detail, err
+}
+
+func (r *WithdrawnRepository) Reverse(ctx context.Context, id uuid.UUID, reversedAt time.Time) (bool, error) {
+	reversed := false
+
+	err := r.storage.Do(ctx, func(tables *memory.Tables) error {
+		withdraw, ok := tables.Withdraws[id]
+		if !ok || withdraw.Status != entity.WithdrawStatusCompleted {
+			return nil
+		}
+
+		withdraw.Status = entity.WithdrawStatusReversed
+		withdraw.ReversedAt = &reversedAt
+		tables.Withdraws[id] = withdraw
+		reversed = true
+		return nil
+	})
+
+	return reversed, err
+}
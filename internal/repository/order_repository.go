@@ -9,6 +9,7 @@ import (
 	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
 )
 
 type OrderRepository struct {
@@ -38,6 +39,58 @@ func (r *OrderRepository) FindUserIDByOrderNumber(ctx context.Context, orderNumb
 	return userID, nil
 }
 
+func (r *OrderRepository) FindByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var order entity.Order
+	err := db.QueryRow(ctx,
+		query.FindOrderByNumber,
+		orderNumber,
+	).Scan(
+		&order.ID,
+		&order.Number,
+		&order.Status,
+		&order.Accrual,
+		&order.CreatedAt,
+		&order.UserID,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query", err)
+	}
+	return &order, nil
+}
+
+func (r *OrderRepository) GetByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var order entity.Order
+	err := db.QueryRow(ctx,
+		query.GetOrderByNumber,
+		orderNumber,
+	).Scan(
+		&order.ID,
+		&order.Number,
+		&order.Status,
+		&order.Accrual,
+		&order.CreatedAt,
+		&order.UserID,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query", err)
+	}
+	return &order, nil
+}
+
 func (r *OrderRepository) Save(ctx context.Context, order *entity.Order) (*entity.Order, error) {
 	db := r.storage.GetExecutor(ctx)
 
@@ -137,17 +190,23 @@ func (r *OrderRepository) GetTotalAccrualByUser(ctx context.Context, userID uuid
 	return totalAccrual, nil
 }
 
-func (r *OrderRepository) UpdateAccrualData(ctx context.Context, number string, accrual float64, status string) error {
+func (r *OrderRepository) UpdateAccrualData(ctx context.Context, number string, fromStatus string, accrual float64, status string, changedAt time.Time) error {
 	db := r.storage.GetExecutor(ctx)
 
-	_, err := db.Exec(ctx,
+	tag, err := db.Exec(ctx,
 		query.UpdateAccrualData,
 		status,
 		accrual,
-		number)
+		number,
+		changedAt,
+		fromStatus)
 	if err != nil {
 		return errs.New(errs.Generic, "failed to execute query ", err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return errs.New(errs.OrderStatusConflict, "order status has been changed concurrently or is final", nil)
+	}
+
 	return nil
 }
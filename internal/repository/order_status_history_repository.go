@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+)
+
+type OrderStatusHistoryRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewOrderStatusHistoryRepository(storage *postgre.PostgreStorage) *OrderStatusHistoryRepository {
+	return &OrderStatusHistoryRepository{storage: storage}
+}
+
+func (r *OrderStatusHistoryRepository) Save(ctx context.Context, history entity.OrderStatusHistory) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertOrderStatusHistory,
+		history.ID,
+		history.OrderID,
+		history.Status,
+		history.Accrual,
+		history.ChangedAt)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
+
+func (r *OrderStatusHistoryRepository) GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var history []entity.OrderStatusHistory
+
+	rows, err := db.Query(ctx, query.GetOrderStatusHistory, orderID)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record entity.OrderStatusHistory
+		err := rows.Scan(
+			&record.ID,
+			&record.OrderID,
+			&record.Status,
+			&record.Accrual,
+			&record.ChangedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan order status history ", err)
+		}
+		history = append(history, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return history, nil
+}
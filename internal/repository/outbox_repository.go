@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type OutboxRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewOutboxRepository(storage *postgre.PostgreStorage) *OutboxRepository {
+	return &OutboxRepository{storage: storage}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, event entity.OutboxEvent) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertOutboxEvent,
+		event.ID,
+		event.EventType,
+		event.UserID,
+		event.Payload,
+		event.CreatedAt)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) GetUndispatched(ctx context.Context, limit int) ([]entity.OutboxEvent, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var events []entity.OutboxEvent
+
+	rows, err := db.Query(ctx, query.GetUndispatchedOutboxEvents, limit)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event entity.OutboxEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.EventType,
+			&event.UserID,
+			&event.Payload,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan outbox event ", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error {
+	db := r.storage.GetExecutor(ctx)
+
+	if _, err := db.Exec(ctx, query.MarkOutboxEventDispatched, dispatchedAt, id); err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
@@ -2,17 +2,35 @@ package query
 
 const (
 	InsertOrUpdateUserData = `
-		INSERT INTO user_data (id, login, password, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO user_data (id, login, password, created_at, role)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (id) DO UPDATE SET login = $2, password = $3;
 	`
 
 	FindUserByLogin = `
-		SELECT id, login, password, created_at
+		SELECT id, login, password, created_at, role
 		FROM user_data
 		WHERE login = $1;
 `
 
+	GetAllUsers = `
+		SELECT id, login, role, created_at
+		FROM user_data
+		ORDER BY created_at
+	`
+
+	UpdateUserRole = `
+		UPDATE user_data
+		SET role = $1
+		WHERE id = $2
+	`
+
+	UpdateUserRoleByLogin = `
+		UPDATE user_data
+		SET role = $1
+		WHERE login = $2
+	`
+
 	InsertOrder = `
 		INSERT INTO "order" (id, number, status, accrual, created_at, user_id)
 		VALUES ($1, $2, $3, $4, $5, $6);
@@ -23,6 +41,18 @@ const (
 		FROM "order" 
 		WHERE number = $1 FOR UPDATE;
 	`
+	FindOrderByNumber = `
+		SELECT id, number, status, accrual, created_at, user_id
+		FROM "order"
+		WHERE number = $1 FOR UPDATE;
+	`
+
+	GetOrderByNumber = `
+		SELECT id, number, status, accrual, created_at, user_id
+		FROM "order"
+		WHERE number = $1
+	`
+
 	GetAllOrdersByUser = `
         SELECT id, number, status, accrual, created_at, user_id
         FROM "order" 
@@ -30,18 +60,53 @@ const (
         ORDER BY created_at DESC`
 
 	InsertWithdraw = `
-		INSERT INTO withdraw (id, sum, created_at, order_id)
-		VALUES ($1, $2, $3, $4);
+		INSERT INTO withdraw (id, order_number, user_id, sum, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
 	`
 
 	GetAllWithdrawDetailsByUser = `
-        SELECT o.number, w.sum, w.created_at
+        SELECT w.id, w.user_id, w.order_number, w.sum, w.status, w.created_at, w.reversed_at
         FROM withdraw w
-        INNER JOIN "order" o ON w.order_id = o.id
-        INNER JOIN user_data u ON o.user_id = u.id
-        WHERE u.id = $1
+        WHERE w.user_id = $1
         ORDER BY w.created_at DESC`
 
+	InsertWithdrawHold = `
+		INSERT INTO withdraw_hold (id, user_id, order_number, sum, status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	FindActiveWithdrawHold = `
+		SELECT id, user_id, order_number, sum, status, created_at, expires_at, resolved_at
+		FROM withdraw_hold
+		WHERE order_number = $1 AND status = 'ACTIVE'
+		FOR UPDATE
+	`
+
+	GetActiveWithdrawHoldsByUser = `
+		SELECT id, user_id, order_number, sum, status, created_at, expires_at, resolved_at
+		FROM withdraw_hold
+		WHERE user_id = $1 AND status = 'ACTIVE'
+		ORDER BY created_at DESC
+	`
+
+	ResolveWithdrawHold = `
+		UPDATE withdraw_hold
+		SET status = $2, resolved_at = $3
+		WHERE id = $1 AND status = 'ACTIVE'
+	`
+
+	ExpireWithdrawHolds = `
+		UPDATE withdraw_hold
+		SET status = 'EXPIRED', resolved_at = $1
+		WHERE status = 'ACTIVE' AND expires_at <= $1
+	`
+
+	GetTotalHeldByUser = `
+		SELECT COALESCE(sum(h.sum), 0)
+		FROM withdraw_hold h
+		WHERE h.user_id = $1 AND h.status = 'ACTIVE'
+	`
+
 	GetTotalAccrualByUser = `
 		SELECT COALESCE(sum(o.accrual), 0)
 		FROM "order" o 
@@ -51,9 +116,19 @@ const (
 	GetTotalWithdrawByUser = `
 	    SELECT COALESCE(sum(w.sum), 0)
         FROM withdraw w
-        INNER JOIN "order" o ON w.order_id = o.id
-        INNER JOIN user_data u ON o.user_id = u.id
-        WHERE u.id = $1
+        WHERE w.user_id = $1 AND w.status = 'COMPLETED'
+`
+
+	FindWithdrawByOrderNumber = `
+		SELECT w.id, w.user_id, w.order_number, w.sum, w.status, w.created_at, w.reversed_at
+		FROM withdraw w
+		WHERE w.order_number = $1
+`
+
+	ReverseWithdraw = `
+		UPDATE withdraw
+		SET status = 'REVERSED', reversed_at = $2
+		WHERE id = $1 AND status = 'COMPLETED'
 `
 
 	GetUnprocessedOrderNumbers = `
@@ -64,7 +139,294 @@ const (
 
 	UpdateAccrualData = `
 	UPDATE "order"
-	SET status = $1, accrual = $2
-	WHERE number = $3
+	SET status = $1, accrual = $2,
+		processed_at = CASE WHEN $1::varchar = 'PROCESSED' THEN $4 ELSE processed_at END
+	WHERE number = $3 AND status = $5 AND status IN ('NEW', 'PROCESSING')
 `
+
+	InsertOrderStatusHistory = `
+		INSERT INTO order_status_history (id, order_id, status, accrual, changed_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	GetOrderStatusHistory = `
+		SELECT id, order_id, status, accrual, changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at
+	`
+
+	FindUserRoleByID = `
+		SELECT role
+		FROM user_data
+		WHERE id = $1
+	`
+
+	FindUserIDByLogin = `
+		SELECT id
+		FROM user_data
+		WHERE login = $1
+	`
+
+	LockUserByID = `
+		SELECT id
+		FROM user_data
+		WHERE id = $1 FOR UPDATE
+	`
+
+	InsertBalanceAdjustment = `
+		INSERT INTO balance_adjustment (id, user_id, kind, amount, reason, operator_id, reference, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`
+
+	GetTotalAdjustmentByUser = `
+		SELECT COALESCE(sum(a.amount), 0)
+		FROM balance_adjustment a
+		WHERE a.user_id = $1
+	`
+
+	GetStatementByUser = `
+		SELECT 'ACCRUAL', o.accrual, o.number, '', '', o.processed_at
+		FROM "order" o
+		WHERE o.status = 'PROCESSED' AND o.user_id = $1
+		UNION ALL
+		SELECT 'WITHDRAWAL', -w.sum, w.order_number, '', '', w.created_at
+		FROM withdraw w
+		WHERE w.user_id = $1
+		UNION ALL
+		SELECT 'REVERSAL', w.sum, w.order_number, '', '', w.reversed_at
+		FROM withdraw w
+		WHERE w.user_id = $1 AND w.status = 'REVERSED'
+		UNION ALL
+		SELECT 'TRANSFER_OUT', -t.sum, '', '', r.login, t.created_at
+		FROM transfer t
+		INNER JOIN user_data r ON t.recipient_id = r.id
+		WHERE t.sender_id = $1
+		UNION ALL
+		SELECT 'TRANSFER_IN', t.sum, '', '', s.login, t.created_at
+		FROM transfer t
+		INNER JOIN user_data s ON t.sender_id = s.id
+		WHERE t.recipient_id = $1
+		UNION ALL
+		SELECT CASE a.kind WHEN 'EXPIRY' THEN 'EXPIRY' ELSE 'ADJUSTMENT' END,
+			a.amount, '', a.reason, COALESCE(a.reference, ''), a.created_at
+		FROM balance_adjustment a
+		WHERE a.user_id = $1
+		ORDER BY 6 DESC
+	`
+
+	GetUsersWithAccrualsCreditedBefore = `
+		SELECT o.user_id
+		FROM "order" o
+		WHERE o.status = 'PROCESSED' AND o.accrual > 0 AND o.processed_at <= $1
+		UNION
+		SELECT t.recipient_id
+		FROM transfer t
+		WHERE t.created_at <= $1
+		UNION
+		SELECT a.user_id
+		FROM balance_adjustment a
+		WHERE a.amount > 0 AND a.created_at <= $1
+	`
+
+	GetAccrualLotsByUser = `
+		SELECT o.accrual, o.processed_at
+		FROM "order" o
+		WHERE o.status = 'PROCESSED' AND o.accrual > 0 AND o.user_id = $1
+		UNION ALL
+		SELECT t.sum, t.created_at
+		FROM transfer t
+		WHERE t.recipient_id = $1
+		UNION ALL
+		SELECT a.amount, a.created_at
+		FROM balance_adjustment a
+		WHERE a.amount > 0 AND a.user_id = $1
+		ORDER BY 2
+	`
+
+	GetTotalDebitByUser = `
+		SELECT
+			(SELECT COALESCE(sum(w.sum), 0)
+			 FROM withdraw w
+			 WHERE w.user_id = $1 AND w.status = 'COMPLETED')
+			+
+			(SELECT COALESCE(sum(h.sum), 0)
+			 FROM withdraw_hold h
+			 WHERE h.user_id = $1 AND h.status = 'ACTIVE')
+			+
+			(SELECT COALESCE(sum(t.sum), 0)
+			 FROM transfer t
+			 WHERE t.sender_id = $1)
+			+
+			(SELECT COALESCE(-sum(a.amount), 0)
+			 FROM balance_adjustment a
+			 WHERE a.user_id = $1 AND a.amount < 0)
+	`
+
+	InsertTransfer = `
+		INSERT INTO transfer (id, sender_id, recipient_id, sum, created_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	GetNetTransferByUser = `
+		SELECT
+			(SELECT COALESCE(sum(t.sum), 0) FROM transfer t WHERE t.recipient_id = $1)
+			-
+			(SELECT COALESCE(sum(t.sum), 0) FROM transfer t WHERE t.sender_id = $1)
+	`
+
+	GetTotalSentByUserSince = `
+		SELECT COALESCE(sum(t.sum), 0)
+		FROM transfer t
+		WHERE t.sender_id = $1 AND t.created_at >= $2
+	`
+
+	GetAccruedByUserSince = `
+		SELECT COALESCE(sum(o.accrual), 0)
+		FROM "order" o
+		WHERE o.status = 'PROCESSED' AND o.user_id = $1 AND o.processed_at >= $2
+	`
+
+	FindUserTier = `
+		SELECT user_id, tier, accrued, tier_since, updated_at
+		FROM user_tier
+		WHERE user_id = $1
+	`
+
+	GetAllUserTiers = `
+		SELECT user_id, tier, accrued, tier_since, updated_at
+		FROM user_tier
+	`
+
+	UpsertUserTier = `
+		INSERT INTO user_tier (user_id, tier, accrued, tier_since, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET tier = EXCLUDED.tier, accrued = EXCLUDED.accrued, tier_since = EXCLUDED.tier_since, updated_at = EXCLUDED.updated_at
+	`
+
+	InsertUserTierHistory = `
+		INSERT INTO user_tier_history (id, user_id, previous_tier, tier, accrued, changed_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6);
+	`
+
+	InsertWebhook = `
+		INSERT INTO webhook (id, user_id, url, secret, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	UpdateWebhookURL = `
+		UPDATE webhook
+		SET url = $1, updated_at = $2
+		WHERE id = $3 AND user_id = $4
+	`
+
+	DeleteWebhook = `
+		DELETE FROM webhook
+		WHERE id = $1 AND user_id = $2
+	`
+
+	FindWebhookByID = `
+		SELECT id, user_id, url, secret, created_at, updated_at
+		FROM webhook
+		WHERE id = $1 AND user_id = $2
+	`
+
+	GetAllWebhooksByUser = `
+		SELECT id, user_id, url, secret, created_at, updated_at
+		FROM webhook
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	InsertOutboxEvent = `
+		INSERT INTO outbox_event (id, event_type, user_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	GetUndispatchedOutboxEvents = `
+		SELECT id, event_type, user_id, payload, created_at
+		FROM outbox_event
+		WHERE dispatched_at IS NULL
+		ORDER BY created_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	MarkOutboxEventDispatched = `
+		UPDATE outbox_event
+		SET dispatched_at = $1
+		WHERE id = $2
+	`
+
+	InsertWebhookDelivery = `
+		INSERT INTO webhook_delivery (id, webhook_id, event_id, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	ClaimDueWebhookDeliveries = `
+		UPDATE webhook_delivery d
+		SET next_attempt_at = $2
+		FROM webhook w, outbox_event e
+		WHERE d.webhook_id = w.id
+		  AND d.event_id = e.id
+		  AND d.id IN (
+			SELECT id
+			FROM webhook_delivery
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING d.id, d.attempts, w.url, w.secret, e.id, e.event_type, e.payload, e.created_at
+	`
+
+	UpdateWebhookDeliveryResult = `
+		UPDATE webhook_delivery
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, delivered_at = $5
+		WHERE id = $6
+	`
+
+	NotifyChannel = `SELECT pg_notify($1, $2)`
+
+	ListenChannel = `LISTEN %s`
+
+	GetFinalizedOrders = `
+		SELECT id, number, status, accrual, created_at, user_id
+		FROM "order"
+		WHERE status IN ('PROCESSED', 'INVALID')
+		ORDER BY created_at
+	`
+
+	GetFinalizedOrdersSample = `
+		SELECT id, number, status, accrual, created_at, user_id
+		FROM "order"
+		WHERE status IN ('PROCESSED', 'INVALID')
+		ORDER BY random()
+		LIMIT $1
+	`
+
+	GetReconciliationCorrectionByOrder = `
+		SELECT COALESCE(sum(a.amount), 0)
+		FROM balance_adjustment a
+		WHERE a.kind = 'RECONCILIATION' AND a.reference = $1
+	`
+
+	InsertReconciliationMismatch = `
+		INSERT INTO reconciliation_mismatch (id, run_id, order_id, order_number, user_id, stored_status, stored_accrual,
+			remote_status, remote_accrual, difference, corrected, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+	`
+
+	IncrementRateLimitCounter = `
+		INSERT INTO rate_limit_counter (key, window_start, hits, expires_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (key, window_start) DO UPDATE SET hits = rate_limit_counter.hits + 1
+		RETURNING hits
+	`
+
+	DeleteExpiredRateLimitCounters = `
+		DELETE FROM rate_limit_counter
+		WHERE expires_at <= $1
+	`
 )
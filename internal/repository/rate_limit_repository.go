@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type RateLimitRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewRateLimitRepository(storage *postgre.PostgreStorage) *RateLimitRepository {
+	return &RateLimitRepository{storage: storage}
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, windowStart time.Time, expiresAt time.Time) (int, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var hits int
+	err := db.QueryRow(ctx, query.IncrementRateLimitCounter, key, windowStart, expiresAt).Scan(&hits)
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return hits, nil
+}
+
+func (r *RateLimitRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx, query.DeleteExpiredRateLimitCounters, now)
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+)
+
+type ReconciliationRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewReconciliationRepository(storage *postgre.PostgreStorage) *ReconciliationRepository {
+	return &ReconciliationRepository{storage: storage}
+}
+
+func (r *ReconciliationRepository) GetFinalizedOrders(ctx context.Context, sampleSize int) ([]entity.Order, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	sql, args := query.GetFinalizedOrders, []any{}
+	if sampleSize > 0 {
+		sql, args = query.GetFinalizedOrdersSample, []any{sampleSize}
+	}
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	var orders []entity.Order
+	for rows.Next() {
+		var order entity.Order
+		if err := rows.Scan(&order.ID, &order.Number, &order.Status, &order.Accrual, &order.CreatedAt, &order.UserID); err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan row ", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return orders, nil
+}
+
+func (r *ReconciliationRepository) GetCorrectionByOrder(ctx context.Context, orderNumber string) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var correction float64
+	err := db.QueryRow(ctx, query.GetReconciliationCorrectionByOrder, orderNumber).Scan(&correction)
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return correction, nil
+}
+
+func (r *ReconciliationRepository) SaveMismatch(ctx context.Context, mismatch entity.ReconciliationMismatch) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertReconciliationMismatch,
+		mismatch.ID,
+		mismatch.RunID,
+		mismatch.OrderID,
+		mismatch.OrderNumber,
+		mismatch.UserID,
+		mismatch.StoredStatus,
+		mismatch.StoredAccrual,
+		mismatch.RemoteStatus,
+		mismatch.RemoteAccrual,
+		mismatch.Difference,
+		mismatch.Corrected,
+		mismatch.DetectedAt,
+	)
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+)
+
+type StatementRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewStatementRepository(storage *postgre.PostgreStorage) *StatementRepository {
+	return &StatementRepository{storage: storage}
+}
+
+func (r *StatementRepository) GetStatementByUser(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var entries []business.StatementEntry
+
+	rows, err := db.Query(ctx, query.GetStatementByUser, userID)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry business.StatementEntry
+		err := rows.Scan(
+			&entry.Type,
+			&entry.Amount,
+			&entry.OrderNumber,
+			&entry.Reason,
+			&entry.Reference,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan statement entry ", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return entries, nil
+}
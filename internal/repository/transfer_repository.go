@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type TransferRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewTransferRepository(storage *postgre.PostgreStorage) *TransferRepository {
+	return &TransferRepository{storage: storage}
+}
+
+func (r *TransferRepository) Save(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertTransfer,
+		transfer.ID,
+		transfer.SenderID,
+		transfer.RecipientID,
+		transfer.Sum,
+		transfer.CreatedAt)
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &transfer, nil
+}
+
+func (r *TransferRepository) GetNetTransferByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var netTransfer float64
+	err := db.QueryRow(ctx,
+		query.GetNetTransferByUser,
+		userID).
+		Scan(&netTransfer)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return netTransfer, nil
+}
+
+func (r *TransferRepository) GetTotalSentByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var totalSent float64
+	err := db.QueryRow(ctx,
+		query.GetTotalSentByUserSince,
+		userID,
+		since).
+		Scan(&totalSent)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return totalSent, nil
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"github.com/ruslanDantsov/gophermart/internal/repository/query"
@@ -29,14 +31,15 @@ func (r *UserRepository) Save(ctx context.Context, userData entity.UserData) err
 		userData.ID,
 		userData.Login,
 		userData.Password,
-		userData.CreatedAt)
+		userData.CreatedAt,
+		userData.Role)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			switch pgErr.Code {
 			case pgerrcode.UniqueViolation:
-				return fmt.Errorf("login not unique: %w", err)
+				return errs.New(errs.UserAlreadyExists, "login not unique ", err)
 			default:
 				return fmt.Errorf("postgresql error when saving user data (code %s): %w", pgErr.Code, err)
 			}
@@ -55,12 +58,13 @@ func (r *UserRepository) FindByLogin(ctx context.Context, login string) (*entity
 		existingLogin     string
 		existingPassword  string
 		existingCreatedAt time.Time
+		existingRole      string
 	)
 
 	err := db.QueryRow(ctx,
 		query.FindUserByLogin,
 		login).
-		Scan(&existingID, &existingLogin, &existingPassword, &existingCreatedAt)
+		Scan(&existingID, &existingLogin, &existingPassword, &existingCreatedAt, &existingRole)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -80,8 +84,108 @@ func (r *UserRepository) FindByLogin(ctx context.Context, login string) (*entity
 		ID:        existingID,
 		Login:     existingLogin,
 		Password:  existingPassword,
+		Role:      existingRole,
 		CreatedAt: existingCreatedAt,
 	}
 
 	return userData, nil
 }
+
+func (r *UserRepository) GetAll(ctx context.Context) ([]entity.UserData, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var users []entity.UserData
+
+	rows, err := db.Query(ctx, query.GetAllUsers)
+	if err != nil {
+		return nil, fmt.Errorf("error on getting users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userData entity.UserData
+		if err := rows.Scan(&userData.ID, &userData.Login, &userData.Role, &userData.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error on scanning user data: %w", err)
+		}
+		users = append(users, userData)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.UpdateUserRole, role, id)
+	if err != nil {
+		return false, fmt.Errorf("error on updating user role: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *UserRepository) UpdateRoleByLogin(ctx context.Context, login string, role string) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.UpdateUserRoleByLogin, role, login)
+	if err != nil {
+		return false, fmt.Errorf("error on updating user role: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *UserRepository) FindIDByLogin(ctx context.Context, login string) (uuid.UUID, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var id uuid.UUID
+	err := db.QueryRow(ctx, query.FindUserIDByLogin, login).Scan(&id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on searching user id: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *UserRepository) FindRoleByID(ctx context.Context, id uuid.UUID) (string, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var role string
+	err := db.QueryRow(ctx, query.FindUserRoleByID, id).Scan(&role)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("error on searching user role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (r *UserRepository) LockByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var lockedID uuid.UUID
+	err := db.QueryRow(ctx, query.LockUserByID, id).Scan(&lockedID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("error on locking user data: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type UserTierRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewUserTierRepository(storage *postgre.PostgreStorage) *UserTierRepository {
+	return &UserTierRepository{storage: storage}
+}
+
+func (r *UserTierRepository) GetAccruedByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var accrued float64
+	err := db.QueryRow(ctx,
+		query.GetAccruedByUserSince,
+		userID,
+		since).
+		Scan(&accrued)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return accrued, nil
+}
+
+func (r *UserTierRepository) FindByUser(ctx context.Context, userID uuid.UUID) (*entity.UserTier, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var userTier entity.UserTier
+	err := db.QueryRow(ctx, query.FindUserTier, userID).Scan(
+		&userTier.UserID,
+		&userTier.Tier,
+		&userTier.Accrued,
+		&userTier.TierSince,
+		&userTier.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &userTier, nil
+}
+
+func (r *UserTierRepository) GetAll(ctx context.Context) ([]entity.UserTier, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	rows, err := db.Query(ctx, query.GetAllUserTiers)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	var userTiers []entity.UserTier
+	for rows.Next() {
+		var userTier entity.UserTier
+		err := rows.Scan(
+			&userTier.UserID,
+			&userTier.Tier,
+			&userTier.Accrued,
+			&userTier.TierSince,
+			&userTier.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan user tier ", err)
+		}
+		userTiers = append(userTiers, userTier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return userTiers, nil
+}
+
+func (r *UserTierRepository) Save(ctx context.Context, userTier entity.UserTier) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.UpsertUserTier,
+		userTier.UserID,
+		userTier.Tier,
+		userTier.Accrued,
+		userTier.TierSince,
+		userTier.UpdatedAt)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
+
+func (r *UserTierRepository) SaveHistory(ctx context.Context, history entity.UserTierHistory) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertUserTierHistory,
+		history.ID,
+		history.UserID,
+		history.PreviousTier,
+		history.Tier,
+		history.Accrued,
+		history.ChangedAt)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
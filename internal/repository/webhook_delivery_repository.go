@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type WebhookDeliveryRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewWebhookDeliveryRepository(storage *postgre.PostgreStorage) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{storage: storage}
+}
+
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery entity.WebhookDelivery) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertWebhookDelivery,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]business.WebhookDeliveryTask, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var tasks []business.WebhookDeliveryTask
+
+	rows, err := db.Query(ctx, query.ClaimDueWebhookDeliveries, now, leaseUntil, limit)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task business.WebhookDeliveryTask
+		err := rows.Scan(
+			&task.DeliveryID,
+			&task.Attempts,
+			&task.URL,
+			&task.Secret,
+			&task.EventID,
+			&task.EventType,
+			&task.Payload,
+			&task.EventCreatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan webhook delivery ", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *WebhookDeliveryRepository) UpdateResult(ctx context.Context, delivery entity.WebhookDelivery) error {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.UpdateWebhookDeliveryResult,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.ID)
+
+	if err != nil {
+		return errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return nil
+}
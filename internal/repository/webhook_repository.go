@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type WebhookRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewWebhookRepository(storage *postgre.PostgreStorage) *WebhookRepository {
+	return &WebhookRepository{storage: storage}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertWebhook,
+		webhook.ID,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.CreatedAt,
+		webhook.UpdatedAt)
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) UpdateURL(ctx context.Context, id uuid.UUID, userID uuid.UUID, url string, updatedAt time.Time) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx,
+		query.UpdateWebhookURL,
+		url,
+		updatedAt,
+		id,
+		userID)
+
+	if err != nil {
+		return false, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.DeleteWebhook, id, userID)
+	if err != nil {
+		return false, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Webhook, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var webhook entity.Webhook
+	err := db.QueryRow(ctx, query.FindWebhookByID, id, userID).Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query", err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var webhooks []entity.Webhook
+
+	rows, err := db.Query(ctx, query.GetAllWebhooksByUser, userID)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var webhook entity.Webhook
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan webhook ", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
+)
+
+type WithdrawHoldRepository struct {
+	storage *postgre.PostgreStorage
+}
+
+func NewWithdrawHoldRepository(storage *postgre.PostgreStorage) *WithdrawHoldRepository {
+	return &WithdrawHoldRepository{storage: storage}
+}
+
+func (r *WithdrawHoldRepository) Save(ctx context.Context, hold entity.WithdrawHold) (*entity.WithdrawHold, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	_, err := db.Exec(ctx,
+		query.InsertWithdrawHold,
+		hold.ID,
+		hold.UserID,
+		hold.OrderNumber,
+		hold.Sum,
+		hold.Status,
+		hold.CreatedAt,
+		hold.ExpiresAt)
+
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &hold, nil
+}
+
+func (r *WithdrawHoldRepository) FindActive(ctx context.Context, orderNumber string) (*entity.WithdrawHold, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var hold entity.WithdrawHold
+	err := db.QueryRow(ctx, query.FindActiveWithdrawHold, orderNumber).Scan(
+		&hold.ID,
+		&hold.UserID,
+		&hold.OrderNumber,
+		&hold.Sum,
+		&hold.Status,
+		&hold.CreatedAt,
+		&hold.ExpiresAt,
+		&hold.ResolvedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &hold, nil
+}
+
+func (r *WithdrawHoldRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var holds []entity.WithdrawHold
+
+	rows, err := db.Query(ctx, query.GetActiveWithdrawHoldsByUser, userID)
+	if err != nil {
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hold entity.WithdrawHold
+		err := rows.Scan(
+			&hold.ID,
+			&hold.UserID,
+			&hold.OrderNumber,
+			&hold.Sum,
+			&hold.Status,
+			&hold.CreatedAt,
+			&hold.ExpiresAt,
+			&hold.ResolvedAt,
+		)
+		if err != nil {
+			return nil, errs.New(errs.Generic, "failed to scan withdraw holds ", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(errs.Generic, "rows iteration error ", err)
+	}
+
+	return holds, nil
+}
+
+func (r *WithdrawHoldRepository) Resolve(ctx context.Context, id uuid.UUID, status string, resolvedAt time.Time) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.ResolveWithdrawHold, id, status, resolvedAt)
+	if err != nil {
+		return false, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *WithdrawHoldRepository) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.ExpireWithdrawHolds, now)
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *WithdrawHoldRepository) GetTotalHeldByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var totalHeld float64
+	err := db.QueryRow(ctx,
+		query.GetTotalHeldByUser,
+		userID).
+		Scan(&totalHeld)
+
+	if err != nil {
+		return 0, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return totalHeld, nil
+}
@@ -2,12 +2,15 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
 	"github.com/ruslanDantsov/gophermart/internal/model/business"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"github.com/ruslanDantsov/gophermart/internal/repository/query"
+	"time"
 )
 
 type WithdrawnRepository struct {
@@ -40,9 +43,11 @@ func (r *WithdrawnRepository) Save(ctx context.Context, withdraw entity.Withdraw
 	_, err := db.Exec(ctx,
 		query.InsertWithdraw,
 		withdraw.ID,
+		withdraw.OrderNumber,
+		withdraw.UserID,
 		withdraw.Sum,
-		withdraw.CreatedAt,
-		withdraw.OrderID)
+		withdraw.Status,
+		withdraw.CreatedAt)
 
 	if err != nil {
 		return nil, errs.New(errs.Generic, "failed to execute query ", err)
@@ -66,9 +71,13 @@ func (r *WithdrawnRepository) GetAllWithdrawDetailsByUser(ctx context.Context, u
 	for rows.Next() {
 		var withdraw business.WithdrawDetail
 		err := rows.Scan(
+			&withdraw.ID,
+			&withdraw.UserID,
 			&withdraw.OrderNumber,
 			&withdraw.Sum,
+			&withdraw.Status,
 			&withdraw.CreatedAt,
+			&withdraw.ReversedAt,
 		)
 		if err != nil {
 			return nil, errs.New(errs.Generic, "failed to scan withdraws ", err)
@@ -82,3 +91,38 @@ func (r *WithdrawnRepository) GetAllWithdrawDetailsByUser(ctx context.Context, u
 
 	return withdraws, nil
 }
+
+func (r *WithdrawnRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	var withdraw business.WithdrawDetail
+	err := db.QueryRow(ctx, query.FindWithdrawByOrderNumber, orderNumber).Scan(
+		&withdraw.ID,
+		&withdraw.UserID,
+		&withdraw.OrderNumber,
+		&withdraw.Sum,
+		&withdraw.Status,
+		&withdraw.CreatedAt,
+		&withdraw.ReversedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return &withdraw, nil
+}
+
+func (r *WithdrawnRepository) Reverse(ctx context.Context, id uuid.UUID, reversedAt time.Time) (bool, error) {
+	db := r.storage.GetExecutor(ctx)
+
+	tag, err := db.Exec(ctx, query.ReverseWithdraw, id, reversedAt)
+	if err != nil {
+		return false, errs.New(errs.Generic, "failed to execute query ", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
 	"go.uber.org/zap"
+	"sync"
+	"sync/atomic"
 )
 
 type UnprocessedOrderService interface {
@@ -13,20 +18,33 @@ type UnprocessedOrderService interface {
 
 type AccrualClient interface {
 	GetAccrualData(ctx context.Context, orderID string) (*view.AccrualResponse, error)
+	CircuitState() string
 }
 
 type AccrualOrderService struct {
 	unprocessedOrderService UnprocessedOrderService
-	accrualClient           AccrualClient
+	providers               *AccrualProviderRegistry
+	workers                 atomic.Int32
 	log                     *zap.Logger
 }
 
-func NewAccrualOrderService(unprocessedOrderService UnprocessedOrderService, accrualClient AccrualClient, log *zap.Logger) *AccrualOrderService {
-	return &AccrualOrderService{
+func NewAccrualOrderService(unprocessedOrderService UnprocessedOrderService, providers *AccrualProviderRegistry, workers int, log *zap.Logger) *AccrualOrderService {
+	s := &AccrualOrderService{
 		unprocessedOrderService: unprocessedOrderService,
-		accrualClient:           accrualClient,
+		providers:               providers,
 		log:                     log,
 	}
+	s.SetWorkers(workers)
+	return s
+}
+
+func (s *AccrualOrderService) SetWorkers(workers int) {
+	s.workers.Store(int32(max(workers, 1)))
+}
+
+type accrualJob struct {
+	orderNumber string
+	provider    *AccrualProvider
 }
 
 func (s *AccrualOrderService) ProcessOrders(ctx context.Context) {
@@ -39,37 +57,147 @@ func (s *AccrualOrderService) ProcessOrders(ctx context.Context) {
 		)
 	}
 
-	processedOrderCount := 0
+	unavailable := make(map[*AccrualProvider]*atomic.Bool)
 	for _, orderNumber := range unprocessedOrderNumbers {
-		accrualResponse, err := s.accrualClient.GetAccrualData(ctx, orderNumber)
-		if err != nil {
-			s.log.Error("Something went wrong on handling request to Accrual service",
-				zap.String("error", err.Error()),
-			)
-			continue
-		}
+		unavailable[s.providers.Resolve(orderNumber)] = &atomic.Bool{}
+	}
 
-		if accrualResponse == nil {
-			s.log.Error("Something went wrong on handling request to Accrual service: Blank response")
-			continue
-		}
+	var processedOrderCount, postponedOrderCount atomic.Int32
+	postpone := func(provider *AccrualProvider) {
+		provider.postponed.Add(1)
+		postponedOrderCount.Add(1)
+	}
 
-		if accrualResponse.Status == view.AccrualOrderRegisteredStatus {
-			continue
-		}
+	jobs := make(chan accrualJob)
+
+	var wg sync.WaitGroup
+	for range s.workers.Load() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if unavailable[job.provider].Load() {
+					postpone(job.provider)
+					continue
+				}
 
-		err = s.unprocessedOrderService.UpdateAccrualData(ctx, accrualResponse.Order, accrualResponse.Accrual, accrualResponse.Status)
-		if err != nil {
-			s.log.Error("Something went wrong on updating accrual data for order",
-				zap.String("error", err.Error()),
-			)
+				processed, err := s.processOrder(ctx, job.provider, job.orderNumber)
+				if err != nil {
+					if unavailable[job.provider].CompareAndSwap(false, true) {
+						s.log.Warn("Accrual provider is unavailable, its remaining orders are postponed to the next run",
+							zap.String("provider", job.provider.name),
+						)
+					}
+					postpone(job.provider)
+					continue
+				}
+				if processed {
+					processedOrderCount.Add(1)
+				}
+			}
+		}()
+	}
+
+	for _, orderNumber := range unprocessedOrderNumbers {
+		provider := s.providers.Resolve(orderNumber)
+		if unavailable[provider].Load() {
+			postpone(provider)
 			continue
 		}
-		processedOrderCount++
+		jobs <- accrualJob{orderNumber: orderNumber, provider: provider}
 	}
+	close(jobs)
+	wg.Wait()
+
 	s.log.Info("Accrual data has been updated",
-		zap.Int("processed_orders", processedOrderCount),
+		zap.Int32("processed_orders", processedOrderCount.Load()),
+		zap.Int32("postponed_orders", postponedOrderCount.Load()),
 	)
 
 	s.log.Info("Process for updating accrual data has been finished")
 }
+
+func (s *AccrualOrderService) processOrder(ctx context.Context, provider *AccrualProvider, orderNumber string) (bool, error) {
+	provider.polled.Add(1)
+	accrualResponse, err := provider.client.GetAccrualData(ctx, orderNumber)
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) && appErr.Code == errs.AccrualUnavailable {
+		return false, err
+	}
+	if err != nil {
+		provider.failed.Add(1)
+		s.log.Error("Something went wrong on handling request to Accrual service",
+			zap.String("provider", provider.name),
+			zap.String("error", err.Error()),
+		)
+		return false, nil
+	}
+
+	if accrualResponse == nil {
+		s.log.Info("Order is not registered in Accrual service",
+			zap.String("provider", provider.name),
+			zap.String("order", orderNumber),
+		)
+		return false, nil
+	}
+
+	if accrualResponse.Status == view.AccrualOrderRegisteredStatus {
+		return false, nil
+	}
+
+	err = s.unprocessedOrderService.UpdateAccrualData(ctx, accrualResponse.Order, accrualResponse.Accrual, accrualResponse.Status)
+	if errors.As(err, &appErr) && appErr.Code == errs.OrderStatusConflict {
+		s.log.Warn("Stale accrual status is ignored",
+			zap.String("provider", provider.name),
+			zap.String("order", accrualResponse.Order),
+			zap.String("status", accrualResponse.Status),
+		)
+		return false, nil
+	}
+	if err != nil {
+		provider.failed.Add(1)
+		s.log.Error("Something went wrong on updating accrual data for order",
+			zap.String("error", err.Error()),
+		)
+		return false, nil
+	}
+	provider.processed.Add(1)
+	return true, nil
+}
+
+func (s *AccrualOrderService) ProviderStats() []business.AccrualProviderStats {
+	return s.providers.Stats()
+}
+
+func (s *AccrualOrderService) CircuitStates() map[string]string {
+	return s.providers.CircuitStates()
+}
+
+func (s *AccrualOrderService) SyncOrder(ctx context.Context, orderNumber string) (*view.AccrualResponse, error) {
+	provider := s.providers.Resolve(orderNumber)
+	accrualResponse, err := provider.client.GetAccrualData(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if accrualResponse == nil {
+		return nil, errs.New(errs.OrderNotFound, "order is not registered in Accrual service", nil)
+	}
+
+	if accrualResponse.Status == view.AccrualOrderRegisteredStatus {
+		return accrualResponse, nil
+	}
+
+	err = s.unprocessedOrderService.UpdateAccrualData(ctx, orderNumber, accrualResponse.Accrual, accrualResponse.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Accrual data has been re-synced manually",
+		zap.String("order", orderNumber),
+		zap.String("provider", provider.name),
+		zap.String("status", accrualResponse.Status),
+	)
+
+	return accrualResponse, nil
+}
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap/zaptest"
 )
@@ -33,6 +36,10 @@ func (m *MockAccrualClient) GetAccrualData(ctx context.Context, orderID string)
 	return args.Get(0).(*view.AccrualResponse), args.Error(1)
 }
 
+func (m *MockAccrualClient) CircuitState() string {
+	return "closed"
+}
+
 func TestAccrualOrderService_ProcessOrders(t *testing.T) {
 	ctx := context.Background()
 	logger := zaptest.NewLogger(t)
@@ -59,7 +66,7 @@ func TestAccrualOrderService_ProcessOrders(t *testing.T) {
 		mockUnprocessedService.On("UpdateAccrualData", ctx, "123", 100.0, "PROCESSED").Return(nil)
 		mockUnprocessedService.On("UpdateAccrualData", ctx, "456", 50.0, "INVALID").Return(nil)
 
-		svc := NewAccrualOrderService(mockUnprocessedService, mockAccrualClient, logger)
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
 		svc.ProcessOrders(ctx)
 
 		mockUnprocessedService.AssertExpectations(t)
@@ -74,7 +81,7 @@ func TestAccrualOrderService_ProcessOrders(t *testing.T) {
 		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return(orderNumbers, nil)
 		mockAccrualClient.On("GetAccrualData", ctx, "123").Return((*view.AccrualResponse)(nil), errors.New("accrual service error"))
 
-		svc := NewAccrualOrderService(mockUnprocessedService, mockAccrualClient, logger)
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
 		svc.ProcessOrders(ctx)
 
 		mockUnprocessedService.AssertExpectations(t)
@@ -93,7 +100,7 @@ func TestAccrualOrderService_ProcessOrders(t *testing.T) {
 			Status:  "REGISTERED",
 		}, nil)
 
-		svc := NewAccrualOrderService(mockUnprocessedService, mockAccrualClient, logger)
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
 		svc.ProcessOrders(ctx)
 
 		mockUnprocessedService.AssertExpectations(t)
@@ -113,10 +120,176 @@ func TestAccrualOrderService_ProcessOrders(t *testing.T) {
 		}, nil)
 		mockUnprocessedService.On("UpdateAccrualData", ctx, "123", 100.0, "PROCESSED").Return(errors.New("update error"))
 
-		svc := NewAccrualOrderService(mockUnprocessedService, mockAccrualClient, logger)
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
+		svc.ProcessOrders(ctx)
+
+		mockUnprocessedService.AssertExpectations(t)
+		mockAccrualClient.AssertExpectations(t)
+	})
+
+	t.Run("stops when accrual service is unavailable", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		mockAccrualClient := new(MockAccrualClient)
+
+		orderNumbers := []string{"123", "456"}
+		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return(orderNumbers, nil)
+		mockAccrualClient.On("GetAccrualData", ctx, "123").
+			Return((*view.AccrualResponse)(nil), errs.New(errs.AccrualUnavailable, "accrual service circuit is open", nil))
+
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
 		svc.ProcessOrders(ctx)
 
 		mockUnprocessedService.AssertExpectations(t)
 		mockAccrualClient.AssertExpectations(t)
+		mockAccrualClient.AssertNotCalled(t, "GetAccrualData", ctx, "456")
+	})
+
+	t.Run("processes orders with concurrent workers", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		mockAccrualClient := new(MockAccrualClient)
+
+		orderNumbers := []string{"1", "2", "3", "4", "5"}
+		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return(orderNumbers, nil)
+		for _, number := range orderNumbers {
+			mockAccrualClient.On("GetAccrualData", ctx, number).Return(&view.AccrualResponse{
+				Order:   number,
+				Accrual: 10.0,
+				Status:  "PROCESSED",
+			}, nil)
+			mockUnprocessedService.On("UpdateAccrualData", ctx, number, 10.0, "PROCESSED").Return(nil)
+		}
+
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 3, logger)
+		svc.ProcessOrders(ctx)
+
+		mockUnprocessedService.AssertExpectations(t)
+		mockAccrualClient.AssertExpectations(t)
+	})
+}
+
+func TestAccrualOrderService_Providers(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("routes orders to matching provider and tracks stats", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		defaultClient := new(MockAccrualClient)
+		partnerClient := new(MockAccrualClient)
+
+		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return([]string{"123", "990", "991"}, nil)
+		defaultClient.On("GetAccrualData", ctx, "123").Return(&view.AccrualResponse{Order: "123", Accrual: 10.0, Status: "PROCESSED"}, nil)
+		partnerClient.On("GetAccrualData", ctx, "990").Return(&view.AccrualResponse{Order: "990", Accrual: 20.0, Status: "PROCESSED"}, nil)
+		partnerClient.On("GetAccrualData", ctx, "991").Return((*view.AccrualResponse)(nil), errors.New("accrual service error"))
+		mockUnprocessedService.On("UpdateAccrualData", ctx, "123", 10.0, "PROCESSED").Return(nil)
+		mockUnprocessedService.On("UpdateAccrualData", ctx, "990", 20.0, "PROCESSED").Return(nil)
+
+		providers := NewAccrualProviderRegistry(defaultClient)
+		assert.NoError(t, providers.Register("partner", "prefix=99", partnerClient))
+
+		svc := NewAccrualOrderService(mockUnprocessedService, providers, 2, logger)
+		svc.ProcessOrders(ctx)
+
+		mockUnprocessedService.AssertExpectations(t)
+		defaultClient.AssertExpectations(t)
+		partnerClient.AssertExpectations(t)
+
+		stats := svc.ProviderStats()
+		assert.Equal(t, []business.AccrualProviderStats{
+			{Name: DefaultAccrualProvider, Circuit: "closed", Polled: 1, Processed: 1},
+			{Name: "partner", Rule: "prefix=99", Circuit: "closed", Polled: 2, Processed: 1, Failed: 1},
+		}, stats)
+	})
+
+	t.Run("keeps polling other providers when one is unavailable", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		defaultClient := new(MockAccrualClient)
+		partnerClient := new(MockAccrualClient)
+
+		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return([]string{"1234", "123", "1235", "456"}, nil)
+		partnerClient.On("GetAccrualData", ctx, "1234").
+			Return((*view.AccrualResponse)(nil), errs.New(errs.AccrualUnavailable, "accrual service circuit is open", nil))
+		defaultClient.On("GetAccrualData", ctx, "123").Return(&view.AccrualResponse{Order: "123", Accrual: 10.0, Status: "PROCESSED"}, nil)
+		defaultClient.On("GetAccrualData", ctx, "456").Return(&view.AccrualResponse{Order: "456", Accrual: 30.0, Status: "PROCESSED"}, nil)
+		mockUnprocessedService.On("UpdateAccrualData", ctx, "123", 10.0, "PROCESSED").Return(nil)
+		mockUnprocessedService.On("UpdateAccrualData", ctx, "456", 30.0, "PROCESSED").Return(nil)
+
+		providers := NewAccrualProviderRegistry(defaultClient)
+		assert.NoError(t, providers.Register("partner", "length=4", partnerClient))
+
+		svc := NewAccrualOrderService(mockUnprocessedService, providers, 1, logger)
+		svc.ProcessOrders(ctx)
+
+		mockUnprocessedService.AssertExpectations(t)
+		defaultClient.AssertExpectations(t)
+		partnerClient.AssertNotCalled(t, "GetAccrualData", ctx, "1235")
+		assert.Equal(t, int64(2), svc.ProviderStats()[1].Postponed)
+	})
+
+	t.Run("does not count unregistered order as failure", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		mockAccrualClient := new(MockAccrualClient)
+
+		mockUnprocessedService.On("GetUnprocessedOrders", ctx).Return([]string{"123"}, nil)
+		mockAccrualClient.On("GetAccrualData", ctx, "123").Return((*view.AccrualResponse)(nil), nil)
+
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
+		svc.ProcessOrders(ctx)
+
+		mockUnprocessedService.AssertNotCalled(t, "UpdateAccrualData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, []business.AccrualProviderStats{
+			{Name: DefaultAccrualProvider, Circuit: "closed", Polled: 1},
+		}, svc.ProviderStats())
+	})
+}
+
+func TestAccrualProviderRegistry_Register(t *testing.T) {
+	providers := NewAccrualProviderRegistry(new(MockAccrualClient))
+
+	assert.NoError(t, providers.Register("partner", `regex=^77\d{8}$`, new(MockAccrualClient)))
+	assert.Error(t, providers.Register("partner", "prefix=1", new(MockAccrualClient)))
+	assert.Error(t, providers.Register("other", "suffix=1", new(MockAccrualClient)))
+	assert.Error(t, providers.Register("other", "length=abc", new(MockAccrualClient)))
+	assert.Error(t, providers.Register("other", "regex=[", new(MockAccrualClient)))
+
+	assert.Equal(t, "partner", providers.Resolve("7712345678").Name())
+	assert.Equal(t, DefaultAccrualProvider, providers.Resolve("771234567").Name())
+}
+
+func TestAccrualOrderService_SyncOrder(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	t.Run("updates order with fresh accrual data", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		mockAccrualClient := new(MockAccrualClient)
+
+		mockAccrualClient.On("GetAccrualData", ctx, "123").Return(&view.AccrualResponse{
+			Order:   "123",
+			Accrual: 70.0,
+			Status:  "PROCESSED",
+		}, nil)
+		mockUnprocessedService.On("UpdateAccrualData", ctx, "123", 70.0, "PROCESSED").Return(nil)
+
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
+		response, err := svc.SyncOrder(ctx, "123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PROCESSED", response.Status)
+		mockUnprocessedService.AssertExpectations(t)
+		mockAccrualClient.AssertExpectations(t)
+	})
+
+	t.Run("returns accrual client error", func(t *testing.T) {
+		mockUnprocessedService := new(MockUnprocessedOrderService)
+		mockAccrualClient := new(MockAccrualClient)
+
+		mockAccrualClient.On("GetAccrualData", ctx, "123").Return((*view.AccrualResponse)(nil), errors.New("accrual service error"))
+
+		svc := NewAccrualOrderService(mockUnprocessedService, NewAccrualProviderRegistry(mockAccrualClient), 1, logger)
+		response, err := svc.SyncOrder(ctx, "123")
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "accrual service error")
+		mockUnprocessedService.AssertNotCalled(t, "UpdateAccrualData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const DefaultAccrualProvider = "default"
+
+type AccrualProvider struct {
+	name      string
+	rule      string
+	match     func(orderNumber string) bool
+	client    AccrualClient
+	polled    atomic.Int64
+	processed atomic.Int64
+	failed    atomic.Int64
+	postponed atomic.Int64
+}
+
+func (p *AccrualProvider) Name() string {
+	return p.name
+}
+
+type AccrualProviderRegistry struct {
+	providers       []*AccrualProvider
+	defaultProvider *AccrualProvider
+}
+
+func NewAccrualProviderRegistry(defaultClient AccrualClient) *AccrualProviderRegistry {
+	return &AccrualProviderRegistry{
+		defaultProvider: &AccrualProvider{
+			name:   DefaultAccrualProvider,
+			match:  func(string) bool { return true },
+			client: defaultClient,
+		},
+	}
+}
+
+func (r *AccrualProviderRegistry) Register(name string, rule string, client AccrualClient) error {
+	if name == "" {
+		return fmt.Errorf("accrual provider name is empty")
+	}
+	for _, provider := range r.all() {
+		if provider.name == name {
+			return fmt.Errorf("accrual provider %q is registered twice", name)
+		}
+	}
+
+	match, err := parseOrderNumberRule(rule)
+	if err != nil {
+		return fmt.Errorf("accrual provider %q: %w", name, err)
+	}
+
+	r.providers = append(r.providers, &AccrualProvider{
+		name:   name,
+		rule:   rule,
+		match:  match,
+		client: client,
+	})
+	return nil
+}
+
+func (r *AccrualProviderRegistry) Resolve(orderNumber string) *AccrualProvider {
+	for _, provider := range r.providers {
+		if provider.match(orderNumber) {
+			return provider
+		}
+	}
+	return r.defaultProvider
+}
+
+func (r *AccrualProviderRegistry) Stats() []business.AccrualProviderStats {
+	providers := r.all()
+	stats := make([]business.AccrualProviderStats, len(providers))
+	for i, provider := range providers {
+		stats[i] = business.AccrualProviderStats{
+			Name:      provider.name,
+			Rule:      provider.rule,
+			Circuit:   provider.client.CircuitState(),
+			Polled:    provider.polled.Load(),
+			Processed: provider.processed.Load(),
+			Failed:    provider.failed.Load(),
+			Postponed: provider.postponed.Load(),
+		}
+	}
+	return stats
+}
+
+func (r *AccrualProviderRegistry) CircuitStates() map[string]string {
+	states := make(map[string]string, len(r.providers)+1)
+	for _, provider := range r.all() {
+		states[provider.name] = provider.client.CircuitState()
+	}
+	return states
+}
+
+func (r *AccrualProviderRegistry) all() []*AccrualProvider {
+	return append([]*AccrualProvider{r.defaultProvider}, r.providers...)
+}
+
+func parseOrderNumberRule(rule string) (func(orderNumber string) bool, error) {
+	kind, value, found := strings.Cut(rule, "=")
+	if !found || value == "" {
+		return nil, fmt.Errorf("invalid order number rule %q, expected prefix=P, regex=R or length=N", rule)
+	}
+
+	switch kind {
+	case "prefix":
+		return func(orderNumber string) bool {
+			return strings.HasPrefix(orderNumber, value)
+		}, nil
+	case "regex":
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order number regex %q: %w", value, err)
+		}
+		return pattern.MatchString, nil
+	case "length":
+		length, err := strconv.Atoi(value)
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("invalid order number length %q", value)
+		}
+		return func(orderNumber string) bool {
+			return len(orderNumber) == length
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown order number rule %q, expected prefix, regex or length", kind)
+	}
+}
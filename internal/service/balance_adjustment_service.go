@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"strings"
+	"time"
+)
+
+type BalanceAdjustmentRepository interface {
+	Save(ctx context.Context, adjustment entity.BalanceAdjustment) (*entity.BalanceAdjustment, error)
+}
+
+type UserLocker interface {
+	LockByID(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type BalanceGetter interface {
+	GetBalance(ctx context.Context, userID uuid.UUID) (*business.Balance, error)
+}
+
+type BalanceAdjustmentService struct {
+	balanceAdjustmentRepository BalanceAdjustmentRepository
+	userLocker                  UserLocker
+	balanceGetter               BalanceGetter
+	outboxRecorder              OutboxRecorder
+	txManager                   TxManager
+}
+
+func NewBalanceAdjustmentService(
+	balanceAdjustmentRepository BalanceAdjustmentRepository,
+	userLocker UserLocker,
+	balanceGetter BalanceGetter,
+	outboxRecorder OutboxRecorder,
+	txManager TxManager,
+) *BalanceAdjustmentService {
+	return &BalanceAdjustmentService{
+		balanceAdjustmentRepository: balanceAdjustmentRepository,
+		userLocker:                  userLocker,
+		balanceGetter:               balanceGetter,
+		outboxRecorder:              outboxRecorder,
+		txManager:                   txManager,
+	}
+}
+
+func (s *BalanceAdjustmentService) AddAdjustment(ctx context.Context, adjustmentCreateCommand command.BalanceAdjustmentCreateCommand, userID uuid.UUID, operatorID uuid.UUID) (*entity.BalanceAdjustment, error) {
+	reason := strings.TrimSpace(adjustmentCreateCommand.Reason)
+	if reason == "" {
+		return nil, errs.New(errs.InvalidAdjustment, "adjustment reason is required", nil)
+	}
+
+	if adjustmentCreateCommand.Amount == 0 {
+		return nil, errs.New(errs.InvalidAdjustment, "adjustment amount must not be zero", nil)
+	}
+
+	var savedAdjustment *entity.BalanceAdjustment
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := s.userLocker.LockByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return errs.New(errs.UserNotFound, "user not found", nil)
+		}
+
+		balance, err := s.balanceGetter.GetBalance(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if balance.Total+adjustmentCreateCommand.Amount < 0 {
+			return errs.New(errs.NotEnoughAccrual, "adjustment would make the balance negative", nil)
+		}
+
+		rawAdjustment := entity.BalanceAdjustment{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Kind:       entity.AdjustmentKindManual,
+			Amount:     adjustmentCreateCommand.Amount,
+			Reason:     reason,
+			OperatorID: operatorID,
+			Reference:  strings.TrimSpace(adjustmentCreateCommand.Reference),
+			CreatedAt:  time.Now(),
+		}
+
+		savedAdjustment, err = s.balanceAdjustmentRepository.Save(ctx, rawAdjustment)
+		if err != nil {
+			return err
+		}
+
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventBalanceAdjusted, userID, toBalanceAdjustmentViewModel(*savedAdjustment))
+	})
+
+	return savedAdjustment, err
+}
+
+func toBalanceAdjustmentViewModel(adjustment entity.BalanceAdjustment) view.BalanceAdjustmentViewModel {
+	return view.BalanceAdjustmentViewModel{
+		ID:         adjustment.ID,
+		Amount:     adjustment.Amount,
+		Reason:     adjustment.Reason,
+		Reference:  adjustment.Reference,
+		OperatorID: adjustment.OperatorID,
+		CreatedAt:  adjustment.CreatedAt,
+	}
+}
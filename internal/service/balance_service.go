@@ -13,15 +13,54 @@ type AccrualAggregatorRepository interface {
 type WithdrawnAggregatorRepository interface {
 	GetTotalWithdrawByUser(ctx context.Context, userID uuid.UUID) (float64, error)
 }
+
+type AdjustmentAggregatorRepository interface {
+	GetTotalAdjustmentByUser(ctx context.Context, userID uuid.UUID) (float64, error)
+}
+
+type HeldAggregatorRepository interface {
+	GetTotalHeldByUser(ctx context.Context, userID uuid.UUID) (float64, error)
+}
+
+type TransferAggregatorRepository interface {
+	GetNetTransferByUser(ctx context.Context, userID uuid.UUID) (float64, error)
+}
+
+type StatementRepository interface {
+	GetStatementByUser(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error)
+}
+
+type ExpiringSoonCalculator interface {
+	GetExpiringSoon(ctx context.Context, userID uuid.UUID) (float64, error)
+}
+
 type BalanceService struct {
-	accrualAggregatorRepository   AccrualAggregatorRepository
-	withdrawnAggregatorRepository WithdrawnAggregatorRepository
+	accrualAggregatorRepository    AccrualAggregatorRepository
+	withdrawnAggregatorRepository  WithdrawnAggregatorRepository
+	adjustmentAggregatorRepository AdjustmentAggregatorRepository
+	heldAggregatorRepository       HeldAggregatorRepository
+	transferAggregatorRepository   TransferAggregatorRepository
+	statementRepository            StatementRepository
+	expiringSoonCalculator         ExpiringSoonCalculator
 }
 
-func NewBalanceService(accrualAggregatorRepository AccrualAggregatorRepository, withdrawnAggregatorRepository WithdrawnAggregatorRepository) *BalanceService {
+func NewBalanceService(
+	accrualAggregatorRepository AccrualAggregatorRepository,
+	withdrawnAggregatorRepository WithdrawnAggregatorRepository,
+	adjustmentAggregatorRepository AdjustmentAggregatorRepository,
+	heldAggregatorRepository HeldAggregatorRepository,
+	transferAggregatorRepository TransferAggregatorRepository,
+	statementRepository StatementRepository,
+	expiringSoonCalculator ExpiringSoonCalculator,
+) *BalanceService {
 	return &BalanceService{
-		accrualAggregatorRepository:   accrualAggregatorRepository,
-		withdrawnAggregatorRepository: withdrawnAggregatorRepository,
+		accrualAggregatorRepository:    accrualAggregatorRepository,
+		withdrawnAggregatorRepository:  withdrawnAggregatorRepository,
+		adjustmentAggregatorRepository: adjustmentAggregatorRepository,
+		heldAggregatorRepository:       heldAggregatorRepository,
+		transferAggregatorRepository:   transferAggregatorRepository,
+		statementRepository:            statementRepository,
+		expiringSoonCalculator:         expiringSoonCalculator,
 	}
 }
 
@@ -34,9 +73,34 @@ func (s *BalanceService) GetBalance(ctx context.Context, userID uuid.UUID) (*bus
 	if err != nil {
 		return nil, err
 	}
+	totalAdjustment, err := s.adjustmentAggregatorRepository.GetTotalAdjustmentByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalHeld, err := s.heldAggregatorRepository.GetTotalHeldByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	netTransfer, err := s.transferAggregatorRepository.GetNetTransferByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expiringSoon, err := s.expiringSoonCalculator.GetExpiringSoon(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	return &business.Balance{
-		Total:     totalAccrual - totalWithdrawn,
-		Withdrawn: totalWithdrawn,
+		Total:        totalAccrual - totalWithdrawn + totalAdjustment + netTransfer - totalHeld,
+		Withdrawn:    totalWithdrawn,
+		Held:         totalHeld,
+		Transferred:  netTransfer,
+		Adjusted:     totalAdjustment,
+		ExpiringSoon: expiringSoon,
 	}, nil
 }
+
+func (s *BalanceService) GetStatement(ctx context.Context, userID uuid.UUID) ([]business.StatementEntry, error) {
+	return s.statementRepository.GetStatementByUser(ctx, userID)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAccrualAggregatorRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccrualAggregatorRepository) GetTotalAccrualByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockWithdrawnAggregatorRepository struct {
+	mock.Mock
+}
+
+func (m *MockWithdrawnAggregatorRepository) GetTotalWithdrawByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockAdjustmentAggregatorRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdjustmentAggregatorRepository) GetTotalAdjustmentByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockHeldAggregatorRepository struct {
+	mock.Mock
+}
+
+func (m *MockHeldAggregatorRepository) GetTotalHeldByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockTransferAggregatorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferAggregatorRepository) GetNetTransferByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockExpiringSoonCalculator struct {
+	mock.Mock
+}
+
+func (m *MockExpiringSoonCalculator) GetExpiringSoon(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func TestBalanceService_GetBalance(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("includes adjustments, transfers and holds in current balance", func(t *testing.T) {
+		accruals := new(MockAccrualAggregatorRepository)
+		withdrawals := new(MockWithdrawnAggregatorRepository)
+		adjustments := new(MockAdjustmentAggregatorRepository)
+
+		accruals.On("GetTotalAccrualByUser", ctx, userID).Return(500.0, nil)
+		withdrawals.On("GetTotalWithdrawByUser", ctx, userID).Return(200.0, nil)
+		adjustments.On("GetTotalAdjustmentByUser", ctx, userID).Return(-50.0, nil)
+		holds := new(MockHeldAggregatorRepository)
+		holds.On("GetTotalHeldByUser", ctx, userID).Return(30.0, nil)
+		transfers := new(MockTransferAggregatorRepository)
+		transfers.On("GetNetTransferByUser", ctx, userID).Return(-70.0, nil)
+		expiringSoon := new(MockExpiringSoonCalculator)
+		expiringSoon.On("GetExpiringSoon", ctx, userID).Return(120.0, nil)
+
+		balance, err := NewBalanceService(accruals, withdrawals, adjustments, holds, transfers, nil, expiringSoon).GetBalance(ctx, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 150.0, balance.Total)
+		assert.Equal(t, -70.0, balance.Transferred)
+		assert.Equal(t, 30.0, balance.Held)
+		assert.Equal(t, 200.0, balance.Withdrawn)
+		assert.Equal(t, -50.0, balance.Adjusted)
+		assert.Equal(t, 120.0, balance.ExpiringSoon)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		accruals := new(MockAccrualAggregatorRepository)
+		withdrawals := new(MockWithdrawnAggregatorRepository)
+		adjustments := new(MockAdjustmentAggregatorRepository)
+
+		accruals.On("GetTotalAccrualByUser", ctx, userID).Return(500.0, nil)
+		withdrawals.On("GetTotalWithdrawByUser", ctx, userID).Return(200.0, nil)
+		adjustments.On("GetTotalAdjustmentByUser", ctx, userID).Return(0.0, errors.New("db is down"))
+
+		balance, err := NewBalanceService(accruals, withdrawals, adjustments, nil, nil, nil, nil).GetBalance(ctx, userID)
+
+		assert.Nil(t, balance)
+		assert.EqualError(t, err, "db is down")
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"math"
+	"time"
+)
+
+const pointsExpiredReason = "Accrued points expired"
+
+type ExpirationRepository interface {
+	GetUsersWithAccrualsCreditedBefore(ctx context.Context, creditedBefore time.Time) ([]uuid.UUID, error)
+	GetAccrualLotsByUser(ctx context.Context, userID uuid.UUID) ([]business.AccrualLot, error)
+	GetTotalDebitByUser(ctx context.Context, userID uuid.UUID) (float64, error)
+}
+
+type ExpirationService struct {
+	txManager                   TxManager
+	expirationRepository        ExpirationRepository
+	userLocker                  UserLocker
+	balanceAdjustmentRepository BalanceAdjustmentRepository
+	outboxRecorder              OutboxRecorder
+	clock                       Clock
+	expirationMonths            int
+	expiringSoonWindow          time.Duration
+	log                         *zap.Logger
+}
+
+func NewExpirationService(
+	txManager TxManager,
+	expirationRepository ExpirationRepository,
+	userLocker UserLocker,
+	balanceAdjustmentRepository BalanceAdjustmentRepository,
+	outboxRecorder OutboxRecorder,
+	clock Clock,
+	expirationMonths int,
+	expiringSoonWindow time.Duration,
+	log *zap.Logger,
+) *ExpirationService {
+	return &ExpirationService{
+		txManager:                   txManager,
+		expirationRepository:        expirationRepository,
+		userLocker:                  userLocker,
+		balanceAdjustmentRepository: balanceAdjustmentRepository,
+		outboxRecorder:              outboxRecorder,
+		clock:                       clock,
+		expirationMonths:            expirationMonths,
+		expiringSoonWindow:          expiringSoonWindow,
+		log:                         log,
+	}
+}
+
+func (s *ExpirationService) Enabled() bool {
+	return s.expirationMonths > 0
+}
+
+func (s *ExpirationService) ExpirePoints(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	now := s.clock.Now()
+	userIDs, err := s.expirationRepository.GetUsersWithAccrualsCreditedBefore(ctx, now.AddDate(0, -s.expirationMonths, 0))
+	if err != nil {
+		s.log.Error("Failed to get users with expirable points", zap.Error(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := s.expireUserPoints(ctx, userID, now); err != nil {
+			s.log.Error("Failed to expire points",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *ExpirationService) GetExpiringSoon(ctx context.Context, userID uuid.UUID) (float64, error) {
+	if !s.Enabled() {
+		return 0, nil
+	}
+
+	lots, err := s.expirationRepository.GetAccrualLotsByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	totalDebit, err := s.expirationRepository.GetTotalDebitByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, expiringSoon := calculateExpiration(lots, totalDebit, s.expirationMonths, s.clock.Now(), s.expiringSoonWindow)
+	return expiringSoon, nil
+}
+
+func (s *ExpirationService) expireUserPoints(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		exists, err := s.userLocker.LockByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return errs.New(errs.UserNotFound, "user not found", nil)
+		}
+
+		lots, err := s.expirationRepository.GetAccrualLotsByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		totalDebit, err := s.expirationRepository.GetTotalDebitByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		expired, _ := calculateExpiration(lots, totalDebit, s.expirationMonths, now, s.expiringSoonWindow)
+		if expired <= 0 {
+			return nil
+		}
+
+		rawAdjustment := entity.BalanceAdjustment{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Kind:      entity.AdjustmentKindExpiry,
+			Amount:    -expired,
+			Reason:    pointsExpiredReason,
+			CreatedAt: now,
+		}
+
+		savedAdjustment, err := s.balanceAdjustmentRepository.Save(ctx, rawAdjustment)
+		if err != nil {
+			return err
+		}
+
+		s.log.Info("Points expired",
+			zap.String("user_id", userID.String()),
+			zap.Float64("amount", expired))
+
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventPointsExpired, userID, toBalanceAdjustmentViewModel(*savedAdjustment))
+	})
+}
+
+func calculateExpiration(lots []business.AccrualLot, totalDebit float64, expirationMonths int, now time.Time, window time.Duration) (float64, float64) {
+	var expired, expiringSoon float64
+
+	for _, lot := range lots {
+		consumed := math.Min(lot.Amount, totalDebit)
+		totalDebit -= consumed
+
+		remaining := lot.Amount - consumed
+		if remaining <= 0 {
+			continue
+		}
+
+		expiresAt := lot.CreditedAt.AddDate(0, expirationMonths, 0)
+		switch {
+		case !expiresAt.After(now):
+			expired += remaining
+		case !expiresAt.After(now.Add(window)):
+			expiringSoon += remaining
+		}
+	}
+
+	return roundPoints(expired), roundPoints(expiringSoon)
+}
+
+func roundPoints(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+type passThroughTxManager struct{}
+
+func (passThroughTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockExpirationRepository struct {
+	mock.Mock
+}
+
+func (m *MockExpirationRepository) GetUsersWithAccrualsCreditedBefore(ctx context.Context, creditedBefore time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, creditedBefore)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockExpirationRepository) GetAccrualLotsByUser(ctx context.Context, userID uuid.UUID) ([]business.AccrualLot, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]business.AccrualLot), args.Error(1)
+}
+
+func (m *MockExpirationRepository) GetTotalDebitByUser(ctx context.Context, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockUserLocker struct {
+	mock.Mock
+}
+
+func (m *MockUserLocker) LockByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockBalanceAdjustmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockBalanceAdjustmentRepository) Save(ctx context.Context, adjustment entity.BalanceAdjustment) (*entity.BalanceAdjustment, error) {
+	args := m.Called(ctx, adjustment)
+	return &adjustment, args.Error(0)
+}
+
+type MockOutboxRecorder struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRecorder) Save(ctx context.Context, event entity.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestCalculateExpiration(t *testing.T) {
+	now := time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+	lots := []business.AccrualLot{
+		{Amount: 100, CreditedAt: now.AddDate(0, -13, 0)},
+		{Amount: 200, CreditedAt: now.AddDate(0, -12, 0)},
+		{Amount: 300, CreditedAt: now.AddDate(0, -12, 10)},
+		{Amount: 400, CreditedAt: now.AddDate(0, -6, 0)},
+	}
+	window := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name             string
+		totalDebit       float64
+		wantExpired      float64
+		wantExpiringSoon float64
+	}{
+		{name: "nothing debited", totalDebit: 0, wantExpired: 300, wantExpiringSoon: 300},
+		{name: "debit consumes oldest lot first", totalDebit: 150, wantExpired: 150, wantExpiringSoon: 300},
+		{name: "debit covers expired lots", totalDebit: 450, wantExpired: 0, wantExpiringSoon: 150},
+		{name: "debit covers everything", totalDebit: 1000, wantExpired: 0, wantExpiringSoon: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired, expiringSoon := calculateExpiration(lots, tt.totalDebit, 12, now, window)
+
+			assert.Equal(t, tt.wantExpired, expired)
+			assert.Equal(t, tt.wantExpiringSoon, expiringSoon)
+		})
+	}
+}
+
+func TestExpirationService_ExpirePoints(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	t.Run("posts expiry movement for unspent expired points", func(t *testing.T) {
+		expirationRepository := new(MockExpirationRepository)
+		userLocker := new(MockUserLocker)
+		adjustmentRepository := new(MockBalanceAdjustmentRepository)
+		outboxRecorder := new(MockOutboxRecorder)
+
+		expirationRepository.On("GetUsersWithAccrualsCreditedBefore", ctx, now.AddDate(0, -12, 0)).Return([]uuid.UUID{userID}, nil)
+		expirationRepository.On("GetAccrualLotsByUser", ctx, userID).Return([]business.AccrualLot{
+			{Amount: 100, CreditedAt: now.AddDate(-1, -1, 0)},
+			{Amount: 50, CreditedAt: now.AddDate(0, -1, 0)},
+		}, nil)
+		expirationRepository.On("GetTotalDebitByUser", ctx, userID).Return(40.0, nil)
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		adjustmentRepository.On("Save", ctx, mock.MatchedBy(func(adjustment entity.BalanceAdjustment) bool {
+			return adjustment.UserID == userID &&
+				adjustment.Kind == entity.AdjustmentKindExpiry &&
+				adjustment.Amount == -60 &&
+				adjustment.OperatorID == uuid.Nil &&
+				adjustment.CreatedAt.Equal(now)
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventPointsExpired && event.UserID == userID
+		})).Return(nil)
+
+		service := NewExpirationService(passThroughTxManager{}, expirationRepository, userLocker, adjustmentRepository,
+			outboxRecorder, fixedClock{now: now}, 12, 30*24*time.Hour, zaptest.NewLogger(t))
+		service.ExpirePoints(ctx)
+
+		adjustmentRepository.AssertExpectations(t)
+		outboxRecorder.AssertExpectations(t)
+	})
+
+	t.Run("does nothing when points were spent", func(t *testing.T) {
+		expirationRepository := new(MockExpirationRepository)
+		userLocker := new(MockUserLocker)
+		adjustmentRepository := new(MockBalanceAdjustmentRepository)
+
+		expirationRepository.On("GetUsersWithAccrualsCreditedBefore", ctx, mock.Anything).Return([]uuid.UUID{userID}, nil)
+		expirationRepository.On("GetAccrualLotsByUser", ctx, userID).Return([]business.AccrualLot{
+			{Amount: 100, CreditedAt: now.AddDate(-2, 0, 0)},
+		}, nil)
+		expirationRepository.On("GetTotalDebitByUser", ctx, userID).Return(100.0, nil)
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+
+		service := NewExpirationService(passThroughTxManager{}, expirationRepository, userLocker, adjustmentRepository,
+			nil, fixedClock{now: now}, 12, 30*24*time.Hour, zaptest.NewLogger(t))
+		service.ExpirePoints(ctx)
+
+		adjustmentRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled policy does not touch storage", func(t *testing.T) {
+		expirationRepository := new(MockExpirationRepository)
+
+		service := NewExpirationService(passThroughTxManager{}, expirationRepository, nil, nil,
+			nil, fixedClock{now: now}, 0, 30*24*time.Hour, zaptest.NewLogger(t))
+		service.ExpirePoints(ctx)
+
+		expiringSoon, err := service.GetExpiringSoon(ctx, userID)
+		require.NoError(t, err)
+		assert.Zero(t, expiringSoon)
+		expirationRepository.AssertNotCalled(t, "GetUsersWithAccrualsCreditedBefore", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExpirationService_GetExpiringSoon(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	expirationRepository := new(MockExpirationRepository)
+	expirationRepository.On("GetAccrualLotsByUser", ctx, userID).Return([]business.AccrualLot{
+		{Amount: 70, CreditedAt: now.AddDate(0, -12, 5)},
+		{Amount: 30, CreditedAt: now.AddDate(0, -2, 0)},
+	}, nil)
+	expirationRepository.On("GetTotalDebitByUser", ctx, userID).Return(20.0, nil)
+
+	service := NewExpirationService(passThroughTxManager{}, expirationRepository, nil, nil,
+		nil, fixedClock{now: now}, 12, 30*24*time.Hour, zaptest.NewLogger(t))
+
+	expiringSoon, err := service.GetExpiringSoon(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, expiringSoon)
+}
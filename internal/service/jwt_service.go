@@ -3,11 +3,17 @@ package service
 import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"sync"
 	"time"
 )
 
+const tokenTTL = time.Hour
+
 type JWTService struct {
-	JWTSecret string
+	mu             sync.RWMutex
+	secret         string
+	previousSecret string
+	previousUntil  time.Time
 }
 
 type TokenResult struct {
@@ -16,19 +22,48 @@ type TokenResult struct {
 }
 
 func NewAuthService(secret string) *JWTService {
-	return &JWTService{JWTSecret: secret}
+	return &JWTService{secret: secret}
+}
+
+func (s *JWTService) RotateSecret(secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret == s.secret {
+		return
+	}
+
+	s.previousSecret = s.secret
+	s.previousUntil = time.Now().Add(tokenTTL)
+	s.secret = secret
 }
 
-func (s *JWTService) GenerateJWT(id uuid.UUID, username string) (*TokenResult, error) {
-	expirationTime := time.Now().Add(time.Hour * 1).Unix()
+func (s *JWTService) VerificationKeys() [][]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := [][]byte{[]byte(s.secret)}
+	if s.previousSecret != "" && time.Now().Before(s.previousUntil) {
+		keys = append(keys, []byte(s.previousSecret))
+	}
+	return keys
+}
+
+func (s *JWTService) GenerateJWT(id uuid.UUID, username string, role string) (*TokenResult, error) {
+	expirationTime := time.Now().Add(tokenTTL).Unix()
 	claims := jwt.MapClaims{
 		"id":       id,
 		"username": username,
+		"role":     role,
 		"exp":      expirationTime,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(s.JWTSecret))
+	s.mu.RLock()
+	secret := s.secret
+	s.mu.RUnlock()
+
+	signed, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ func TestJWTService_GenerateJWT(t *testing.T) {
 	type args struct {
 		id       uuid.UUID
 		username string
+		role     string
 		secret   string
 	}
 	tests := []struct {
@@ -25,6 +27,7 @@ func TestJWTService_GenerateJWT(t *testing.T) {
 			args: args{
 				id:       uuid.New(),
 				username: "testuser",
+				role:     "ADMIN",
 				secret:   "supersecretkey",
 			},
 			wantErr: false,
@@ -40,7 +43,7 @@ func TestJWTService_GenerateJWT(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			service := NewAuthService(tt.args.secret)
 
-			got, err := service.GenerateJWT(tt.args.id, tt.args.username)
+			got, err := service.GenerateJWT(tt.args.id, tt.args.username, tt.args.role)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, got)
@@ -50,7 +53,44 @@ func TestJWTService_GenerateJWT(t *testing.T) {
 
 				parts := strings.Split(got.AccessToken, ".")
 				assert.Len(t, parts, 3, "JWT should have 3 parts")
+
+				claims := jwt.MapClaims{}
+				_, err := jwt.ParseWithClaims(got.AccessToken, claims, func(token *jwt.Token) (interface{}, error) {
+					return []byte(tt.args.secret), nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, tt.args.role, claims["role"])
 			}
 		})
 	}
 }
+
+func TestJWTService_RotateSecret(t *testing.T) {
+	service := NewAuthService("old-secret")
+	oldToken, err := service.GenerateJWT(uuid.New(), "testuser", "USER")
+	require.NoError(t, err)
+
+	service.RotateSecret("new-secret")
+	newToken, err := service.GenerateJWT(uuid.New(), "testuser", "USER")
+	require.NoError(t, err)
+
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		keySet := jwt.VerificationKeySet{}
+		for _, key := range service.VerificationKeys() {
+			keySet.Keys = append(keySet.Keys, key)
+		}
+		return keySet, nil
+	}
+
+	_, err = jwt.Parse(newToken.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte("new-secret"), nil
+	})
+	require.NoError(t, err, "new tokens are signed with the new secret")
+
+	_, err = jwt.Parse(oldToken.AccessToken, keyFunc)
+	require.NoError(t, err, "tokens signed with the previous secret are accepted until they expire")
+
+	service.RotateSecret("newest-secret")
+	_, err = jwt.Parse(oldToken.AccessToken, keyFunc)
+	require.Error(t, err, "only one previous secret is kept")
+}
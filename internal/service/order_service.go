@@ -5,9 +5,10 @@ import (
 	"github.com/ShiraazMoollatjie/goluhn"
 	"github.com/google/uuid"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
 	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
-	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"time"
 )
@@ -16,18 +17,53 @@ type OrderRepository interface {
 	Save(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Order, error)
 	GetUnprocessedOrders(ctx context.Context) ([]string, error)
-	UpdateAccrualData(ctx context.Context, number string, accrual float64, status string) error
+	UpdateAccrualData(ctx context.Context, number string, fromStatus string, accrual float64, status string, changedAt time.Time) error
 	FindUserIDByOrderNumber(ctx context.Context, orderNumber string) (uuid.UUID, error)
+	FindByNumber(ctx context.Context, orderNumber string) (*entity.Order, error)
 }
+
+type OrderEventPublisher interface {
+	Publish(ctx context.Context, event business.OrderStatusEvent)
+}
+
+type OrderStatusHistoryRecorder interface {
+	Save(ctx context.Context, history entity.OrderStatusHistory) error
+}
+
+type TierRecalculator interface {
+	RecalculateTier(ctx context.Context, userID uuid.UUID, now time.Time) error
+}
+
+var orderStatusOutboxEvents = map[string]string{
+	entity.OrderProcessingStatus: entity.OutboxEventOrderProcessing,
+	entity.OrderProcessedStatus:  entity.OutboxEventOrderProcessed,
+	entity.OrderInvalidStatus:    entity.OutboxEventOrderInvalid,
+}
+
 type OrderService struct {
-	storage         *postgre.PostgreStorage
-	orderRepository OrderRepository
+	txManager                  TxManager
+	orderRepository            OrderRepository
+	orderEventPublisher        OrderEventPublisher
+	outboxRecorder             OutboxRecorder
+	orderStatusHistoryRecorder OrderStatusHistoryRecorder
+	tierRecalculator           TierRecalculator
 }
 
-func NewOrderService(orderRepository OrderRepository, storage *postgre.PostgreStorage) *OrderService {
+func NewOrderService(
+	orderRepository OrderRepository,
+	txManager TxManager,
+	orderEventPublisher OrderEventPublisher,
+	outboxRecorder OutboxRecorder,
+	orderStatusHistoryRecorder OrderStatusHistoryRecorder,
+	tierRecalculator TierRecalculator,
+) *OrderService {
 	return &OrderService{
-		orderRepository: orderRepository,
-		storage:         storage,
+		orderRepository:            orderRepository,
+		txManager:                  txManager,
+		orderEventPublisher:        orderEventPublisher,
+		outboxRecorder:             outboxRecorder,
+		orderStatusHistoryRecorder: orderStatusHistoryRecorder,
+		tierRecalculator:           tierRecalculator,
 	}
 }
 
@@ -48,7 +84,7 @@ func (s *OrderService) AddOrder(ctx context.Context, orderCreateCommand command.
 	}
 
 	var savedOrder *entity.Order
-	err := s.storage.WithTx(ctx, func(ctx context.Context) error {
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
 		var existingUserID uuid.UUID
 		existingUserID, err := s.orderRepository.FindUserIDByOrderNumber(ctx, rawOrder.Number)
 		if err != nil {
@@ -67,6 +103,20 @@ func (s *OrderService) AddOrder(ctx context.Context, orderCreateCommand command.
 			return err
 		}
 
+		if err := s.recordStatusChange(ctx, rawOrder.ID, rawOrder.Status, rawOrder.Accrual, rawOrder.CreatedAt); err != nil {
+			return err
+		}
+
+		data := view.OrderViewModel{
+			Number:     rawOrder.Number,
+			Status:     rawOrder.Status,
+			Accrual:    rawOrder.Accrual,
+			UploadedAt: rawOrder.CreatedAt,
+		}
+		if err := recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventOrderCreated, authUserID, data); err != nil {
+			return err
+		}
+
 		savedOrder = rawOrder
 		return nil
 	})
@@ -76,6 +126,10 @@ func (s *OrderService) AddOrder(ctx context.Context, orderCreateCommand command.
 
 func (s *OrderService) GetOrders(ctx context.Context) ([]entity.Order, error) {
 	userID := ctx.Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	return s.GetOrdersByUser(ctx, userID)
+}
+
+func (s *OrderService) GetOrdersByUser(ctx context.Context, userID uuid.UUID) ([]entity.Order, error) {
 	orders, err := s.orderRepository.GetAllByUser(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -93,6 +147,86 @@ func (s *OrderService) GetUnprocessedOrders(ctx context.Context) ([]string, erro
 	return numbers, nil
 }
 
-func (s *OrderService) UpdateAccrualData(ctx context.Context, number string, accrual float64, status string) error {
-	return s.orderRepository.UpdateAccrualData(ctx, number, accrual, status)
+func (s *OrderService) UpdateAccrualData(ctx context.Context, number string, accrual float64, accrualStatus string) error {
+	status, err := OrderStatusFromAccrual(accrualStatus)
+	if err != nil {
+		return err
+	}
+
+	var event *business.OrderStatusEvent
+
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepository.FindByNumber(ctx, number)
+		if err != nil {
+			return err
+		}
+
+		if order == nil {
+			return errs.New(errs.OrderNotFound, "order not found", nil)
+		}
+
+		if order.Status == status && order.Accrual == accrual {
+			return nil
+		}
+
+		if err := validateOrderStatusTransition(number, order.Status, status); err != nil {
+			return err
+		}
+
+		changedAt := time.Now()
+		if err := s.orderRepository.UpdateAccrualData(ctx, number, order.Status, accrual, status, changedAt); err != nil {
+			return err
+		}
+
+		if err := s.recordStatusChange(ctx, order.ID, status, accrual, changedAt); err != nil {
+			return err
+		}
+
+		if status == entity.OrderProcessedStatus && accrual > 0 {
+			if err := s.tierRecalculator.RecalculateTier(ctx, order.UserID, changedAt); err != nil {
+				return err
+			}
+		}
+
+		if eventType, ok := orderStatusOutboxEvents[status]; ok {
+			data := view.OrderViewModel{
+				Number:     number,
+				Status:     status,
+				Accrual:    accrual,
+				UploadedAt: order.CreatedAt,
+			}
+			if err := recordOutboxEvent(ctx, s.outboxRecorder, eventType, order.UserID, data); err != nil {
+				return err
+			}
+		}
+
+		event = &business.OrderStatusEvent{
+			UserID:    order.UserID,
+			Number:    number,
+			Status:    status,
+			Accrual:   accrual,
+			UpdatedAt: changedAt,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if event != nil {
+		s.orderEventPublisher.Publish(ctx, *event)
+	}
+
+	return nil
+}
+
+func (s *OrderService) recordStatusChange(ctx context.Context, orderID uuid.UUID, status string, accrual float64, changedAt time.Time) error {
+	return s.orderStatusHistoryRecorder.Save(ctx, entity.OrderStatusHistory{
+		ID:        uuid.New(),
+		OrderID:   orderID,
+		Status:    status,
+		Accrual:   accrual,
+		ChangedAt: changedAt,
+	})
 }
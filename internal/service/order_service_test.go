@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) Save(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	args := m.Called(ctx, order)
+	return order, args.Error(0)
+}
+
+func (m *MockOrderRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Order, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetUnprocessedOrders(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateAccrualData(ctx context.Context, number string, fromStatus string, accrual float64, status string, changedAt time.Time) error {
+	args := m.Called(ctx, number, fromStatus, accrual, status, changedAt)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) FindUserIDByOrderNumber(ctx context.Context, orderNumber string) (uuid.UUID, error) {
+	args := m.Called(ctx, orderNumber)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockOrderRepository) FindByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	args := m.Called(ctx, orderNumber)
+	order, _ := args.Get(0).(*entity.Order)
+	return order, args.Error(1)
+}
+
+type MockOrderEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockOrderEventPublisher) Publish(ctx context.Context, event business.OrderStatusEvent) {
+	m.Called(ctx, event)
+}
+
+type MockOrderStatusHistoryRecorder struct {
+	mock.Mock
+}
+
+func (m *MockOrderStatusHistoryRecorder) Save(ctx context.Context, history entity.OrderStatusHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+type MockTierRecalculator struct {
+	mock.Mock
+}
+
+func (m *MockTierRecalculator) RecalculateTier(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, userID, now)
+	return args.Error(0)
+}
+
+func TestOrderService_UpdateAccrualData(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("credits processed accrual order", func(t *testing.T) {
+		orderRepository := new(MockOrderRepository)
+		publisher := new(MockOrderEventPublisher)
+		outboxRecorder := new(MockOutboxRecorder)
+		historyRecorder := new(MockOrderStatusHistoryRecorder)
+		tierRecalculator := new(MockTierRecalculator)
+		order := &entity.Order{ID: uuid.New(), Number: "79927398713", Status: entity.OrderNewStatus, UserID: userID}
+
+		orderRepository.On("FindByNumber", ctx, order.Number).Return(order, nil)
+		orderRepository.On("UpdateAccrualData", ctx, order.Number, entity.OrderNewStatus, 300.0, entity.OrderProcessedStatus, mock.Anything).Return(nil)
+		historyRecorder.On("Save", ctx, mock.Anything).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventOrderProcessed
+		})).Return(nil)
+		publisher.On("Publish", ctx, mock.Anything).Return()
+		tierRecalculator.On("RecalculateTier", ctx, userID, mock.Anything).Return(nil)
+
+		service := NewOrderService(orderRepository, passThroughTxManager{}, publisher, outboxRecorder, historyRecorder, tierRecalculator)
+		err := service.UpdateAccrualData(ctx, order.Number, 300, view.AccrualOrderProcessedStatus)
+
+		require.NoError(t, err)
+		orderRepository.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+		tierRecalculator.AssertExpectations(t)
+	})
+
+	t.Run("never credits a withdrawal order number", func(t *testing.T) {
+		orderRepository := new(MockOrderRepository)
+		publisher := new(MockOrderEventPublisher)
+
+		orderRepository.On("FindByNumber", ctx, "12345678903").Return(nil, nil)
+
+		service := NewOrderService(orderRepository, passThroughTxManager{}, publisher, nil, nil, nil)
+		err := service.UpdateAccrualData(ctx, "12345678903", 300, view.AccrualOrderProcessedStatus)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.OrderNotFound, appErr.Code)
+		orderRepository.AssertNotCalled(t, "UpdateAccrualData", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ignores replayed status of final order", func(t *testing.T) {
+		orderRepository := new(MockOrderRepository)
+		publisher := new(MockOrderEventPublisher)
+		order := &entity.Order{ID: uuid.New(), Number: "79927398713", Status: entity.OrderProcessedStatus, Accrual: 300, UserID: userID}
+
+		orderRepository.On("FindByNumber", ctx, order.Number).Return(order, nil)
+
+		service := NewOrderService(orderRepository, passThroughTxManager{}, publisher, nil, nil, nil)
+		err := service.UpdateAccrualData(ctx, order.Number, 300, view.AccrualOrderProcessedStatus)
+
+		require.NoError(t, err)
+		orderRepository.AssertNotCalled(t, "UpdateAccrualData", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	rejected := []struct {
+		name          string
+		status        string
+		accrual       float64
+		accrualStatus string
+		newAccrual    float64
+	}{
+		{"rejects late PROCESSING of processed order", entity.OrderProcessedStatus, 300, view.AccrualOrderProcessingStatus, 0},
+		{"rejects accrual overwrite of processed order", entity.OrderProcessedStatus, 300, view.AccrualOrderProcessedStatus, 900},
+		{"rejects processing of invalid order", entity.OrderInvalidStatus, 0, view.AccrualOrderProcessedStatus, 300},
+		{"rejects REGISTERED of processing order", entity.OrderProcessingStatus, 0, view.AccrualOrderRegisteredStatus, 0},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			orderRepository := new(MockOrderRepository)
+			publisher := new(MockOrderEventPublisher)
+			order := &entity.Order{ID: uuid.New(), Number: "79927398713", Status: tt.status, Accrual: tt.accrual, UserID: userID}
+
+			orderRepository.On("FindByNumber", ctx, order.Number).Return(order, nil)
+
+			service := NewOrderService(orderRepository, passThroughTxManager{}, publisher, nil, nil, nil)
+			err := service.UpdateAccrualData(ctx, order.Number, tt.newAccrual, tt.accrualStatus)
+
+			var appErr *errs.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, errs.OrderStatusConflict, appErr.Code)
+			orderRepository.AssertNotCalled(t, "UpdateAccrualData", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("reports concurrent change detected by repository", func(t *testing.T) {
+		orderRepository := new(MockOrderRepository)
+		historyRecorder := new(MockOrderStatusHistoryRecorder)
+		order := &entity.Order{ID: uuid.New(), Number: "79927398713", Status: entity.OrderNewStatus, UserID: userID}
+
+		orderRepository.On("FindByNumber", ctx, order.Number).Return(order, nil)
+		orderRepository.On("UpdateAccrualData", ctx, order.Number, entity.OrderNewStatus, 0.0, entity.OrderProcessingStatus, mock.Anything).
+			Return(errs.New(errs.OrderStatusConflict, "order status has been changed concurrently or is final", nil))
+
+		service := NewOrderService(orderRepository, passThroughTxManager{}, new(MockOrderEventPublisher), nil, historyRecorder, nil)
+		err := service.UpdateAccrualData(ctx, order.Number, 0, view.AccrualOrderProcessingStatus)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.OrderStatusConflict, appErr.Code)
+		historyRecorder.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails on unknown accrual status", func(t *testing.T) {
+		orderRepository := new(MockOrderRepository)
+
+		service := NewOrderService(orderRepository, passThroughTxManager{}, new(MockOrderEventPublisher), nil, nil, nil)
+		err := service.UpdateAccrualData(ctx, "79927398713", 0, "DONE")
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.UnknownAccrualStatus, appErr.Code)
+		orderRepository.AssertNotCalled(t, "FindByNumber", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"slices"
+)
+
+var accrualOrderStatuses = map[string]string{
+	view.AccrualOrderRegisteredStatus: entity.OrderNewStatus,
+	view.AccrualOrderProcessingStatus: entity.OrderProcessingStatus,
+	view.AccrualOrderInvalidStatus:    entity.OrderInvalidStatus,
+	view.AccrualOrderProcessedStatus:  entity.OrderProcessedStatus,
+}
+
+var orderStatusTransitions = map[string][]string{
+	entity.OrderNewStatus:        {entity.OrderNewStatus, entity.OrderProcessingStatus, entity.OrderProcessedStatus, entity.OrderInvalidStatus},
+	entity.OrderProcessingStatus: {entity.OrderProcessingStatus, entity.OrderProcessedStatus, entity.OrderInvalidStatus},
+}
+
+func OrderStatusFromAccrual(accrualStatus string) (string, error) {
+	status, ok := accrualOrderStatuses[accrualStatus]
+	if !ok {
+		return "", errs.New(errs.UnknownAccrualStatus, fmt.Sprintf("unknown accrual status %q", accrualStatus), nil)
+	}
+	return status, nil
+}
+
+func validateOrderStatusTransition(number string, from string, to string) error {
+	if !slices.Contains(orderStatusTransitions[from], to) {
+		return errs.New(errs.OrderStatusConflict, fmt.Sprintf("order %s cannot change status from %s to %s", number, from, to), nil)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatusFromAccrual(t *testing.T) {
+	tests := []struct {
+		accrualStatus string
+		want          string
+	}{
+		{view.AccrualOrderRegisteredStatus, entity.OrderNewStatus},
+		{view.AccrualOrderProcessingStatus, entity.OrderProcessingStatus},
+		{view.AccrualOrderInvalidStatus, "INVALID"},
+		{view.AccrualOrderProcessedStatus, entity.OrderProcessedStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accrualStatus, func(t *testing.T) {
+			status, err := OrderStatusFromAccrual(tt.accrualStatus)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := OrderStatusFromAccrual(" INVALID")
+
+		var appErr *errs.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, errs.UnknownAccrualStatus, appErr.Code)
+	})
+}
+
+func TestValidateOrderStatusTransition(t *testing.T) {
+	statuses := []string{entity.OrderNewStatus, entity.OrderProcessingStatus, entity.OrderProcessedStatus, entity.OrderInvalidStatus}
+	allowed := map[string][]string{
+		entity.OrderNewStatus:        {entity.OrderNewStatus, entity.OrderProcessingStatus, entity.OrderProcessedStatus, entity.OrderInvalidStatus},
+		entity.OrderProcessingStatus: {entity.OrderProcessingStatus, entity.OrderProcessedStatus, entity.OrderInvalidStatus},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(from+" to "+to, func(t *testing.T) {
+				err := validateOrderStatusTransition("12345678903", from, to)
+				if slices.Contains(allowed[from], to) {
+					assert.NoError(t, err)
+					return
+				}
+
+				var appErr *errs.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, errs.OrderStatusConflict, appErr.Code)
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+)
+
+type OrderFinder interface {
+	GetByNumber(ctx context.Context, orderNumber string) (*entity.Order, error)
+}
+
+type OrderStatusHistoryRepository interface {
+	GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error)
+}
+
+type OrderTimelineService struct {
+	orderFinder                  OrderFinder
+	orderStatusHistoryRepository OrderStatusHistoryRepository
+}
+
+func NewOrderTimelineService(orderFinder OrderFinder, orderStatusHistoryRepository OrderStatusHistoryRepository) *OrderTimelineService {
+	return &OrderTimelineService{
+		orderFinder:                  orderFinder,
+		orderStatusHistoryRepository: orderStatusHistoryRepository,
+	}
+}
+
+func (s *OrderTimelineService) GetOrderTimeline(ctx context.Context, number string) (*business.OrderTimeline, error) {
+	order, err := s.orderFinder.GetByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, errs.New(errs.OrderNotFound, "order not found", nil)
+	}
+
+	history, err := s.orderStatusHistoryRepository.GetAllByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &business.OrderTimeline{
+		Order:   *order,
+		History: history,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOrderFinder struct {
+	mock.Mock
+}
+
+func (m *MockOrderFinder) GetByNumber(ctx context.Context, orderNumber string) (*entity.Order, error) {
+	args := m.Called(ctx, orderNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Order), args.Error(1)
+}
+
+type MockOrderStatusHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderStatusHistoryRepository) GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]entity.OrderStatusHistory), args.Error(1)
+}
+
+func TestOrderTimelineService_GetOrderTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns order with its status history", func(t *testing.T) {
+		orderFinder := new(MockOrderFinder)
+		historyRepository := new(MockOrderStatusHistoryRepository)
+
+		order := &entity.Order{ID: uuid.New(), Number: "12345678903", Status: entity.OrderProcessedStatus, Accrual: 500}
+		history := []entity.OrderStatusHistory{
+			{OrderID: order.ID, Status: entity.OrderNewStatus, ChangedAt: time.Now().Add(-time.Minute)},
+			{OrderID: order.ID, Status: entity.OrderProcessedStatus, Accrual: 500, ChangedAt: time.Now()},
+		}
+
+		orderFinder.On("GetByNumber", ctx, "12345678903").Return(order, nil)
+		historyRepository.On("GetAllByOrder", ctx, order.ID).Return(history, nil)
+
+		timeline, err := NewOrderTimelineService(orderFinder, historyRepository).GetOrderTimeline(ctx, "12345678903")
+
+		require.NoError(t, err)
+		assert.Equal(t, *order, timeline.Order)
+		assert.Equal(t, history, timeline.History)
+	})
+
+	t.Run("fails for unknown order", func(t *testing.T) {
+		orderFinder := new(MockOrderFinder)
+		historyRepository := new(MockOrderStatusHistoryRepository)
+
+		orderFinder.On("GetByNumber", ctx, "0").Return(nil, nil)
+
+		timeline, err := NewOrderTimelineService(orderFinder, historyRepository).GetOrderTimeline(ctx, "0")
+
+		assert.Nil(t, timeline)
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.OrderNotFound, appErr.Code)
+		historyRepository.AssertNotCalled(t, "GetAllByOrder", mock.Anything, mock.Anything)
+	})
+}
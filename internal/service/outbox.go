@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"time"
+)
+
+type OutboxRecorder interface {
+	Save(ctx context.Context, event entity.OutboxEvent) error
+}
+
+func recordOutboxEvent(ctx context.Context, outboxRecorder OutboxRecorder, eventType string, userID uuid.UUID, data easyjson.Marshaler) error {
+	payload, err := easyjson.Marshal(data)
+	if err != nil {
+		return errs.New(errs.Generic, "failed to marshal outbox event payload", err)
+	}
+
+	return outboxRecorder.Save(ctx, entity.OutboxEvent{
+		ID:        uuid.New(),
+		EventType: eventType,
+		UserID:    userID,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	})
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"time"
+)
+
+type RateLimitStore interface {
+	Hit(ctx context.Context, key string, windowStart time.Time, expiresAt time.Time) (int, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type RateLimitService struct {
+	rules map[string]business.RateLimitRule
+	store RateLimitStore
+	clock Clock
+}
+
+func NewRateLimitService(rules []business.RateLimitRule, store RateLimitStore, clock Clock) *RateLimitService {
+	s := &RateLimitService{
+		rules: make(map[string]business.RateLimitRule, len(rules)),
+		store: store,
+		clock: clock,
+	}
+	for _, rule := range rules {
+		s.rules[rule.Method+" "+rule.Path] = rule
+	}
+	return s
+}
+
+func (s *RateLimitService) Allow(ctx context.Context, method string, path string, client string) (*business.RateLimitDecision, error) {
+	rule, ok := s.rules[method+" "+path]
+	if !ok {
+		return nil, nil
+	}
+
+	now := s.clock.Now()
+	windowStart := now.Truncate(rule.Window)
+	windowEnd := windowStart.Add(rule.Window)
+
+	hits, err := s.store.Hit(ctx, method+" "+path+" "+client, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &business.RateLimitDecision{
+		Allowed:   hits <= rule.Requests,
+		Limit:     rule.Requests,
+		Remaining: max(rule.Requests-hits, 0),
+		Reset:     windowEnd.Sub(now),
+	}, nil
+}
+
+func (s *RateLimitService) DeleteExpired(ctx context.Context) error {
+	return s.store.DeleteExpired(ctx, s.clock.Now())
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+func (m *MockRateLimitStore) Hit(ctx context.Context, key string, windowStart time.Time, expiresAt time.Time) (int, error) {
+	args := m.Called(ctx, key, windowStart, expiresAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRateLimitStore) DeleteExpired(ctx context.Context, now time.Time) error {
+	args := m.Called(ctx, now)
+	return args.Error(0)
+}
+
+func TestRateLimitService_Allow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 10, 9, 0, 45, 0, time.UTC)
+	windowStart := time.Date(2025, time.August, 10, 9, 0, 0, 0, time.UTC)
+	rules := []business.RateLimitRule{{Method: "POST", Path: "/api/user/orders", Requests: 2, Window: time.Minute}}
+
+	tests := []struct {
+		name          string
+		hits          int
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{name: "first request in window", hits: 1, wantAllowed: true, wantRemaining: 1},
+		{name: "last allowed request", hits: 2, wantAllowed: true, wantRemaining: 0},
+		{name: "request over the limit", hits: 3, wantAllowed: false, wantRemaining: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(MockRateLimitStore)
+			store.On("Hit", ctx, "POST /api/user/orders user:42", windowStart, windowStart.Add(time.Minute)).Return(tt.hits, nil)
+
+			svc := NewRateLimitService(rules, store, fixedClock{now: now})
+			decision, err := svc.Allow(ctx, "POST", "/api/user/orders", "user:42")
+
+			require.NoError(t, err)
+			require.NotNil(t, decision)
+			assert.Equal(t, tt.wantAllowed, decision.Allowed)
+			assert.Equal(t, 2, decision.Limit)
+			assert.Equal(t, tt.wantRemaining, decision.Remaining)
+			assert.Equal(t, 15*time.Second, decision.Reset)
+			store.AssertExpectations(t)
+		})
+	}
+
+	t.Run("route without limit is not counted", func(t *testing.T) {
+		store := new(MockRateLimitStore)
+
+		svc := NewRateLimitService(rules, store, fixedClock{now: now})
+		decision, err := svc.Allow(ctx, "GET", "/api/user/orders", "user:42")
+
+		require.NoError(t, err)
+		assert.Nil(t, decision)
+		store.AssertNotCalled(t, "Hit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
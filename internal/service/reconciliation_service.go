@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+)
+
+const accrualCorrectedReason = "Accrual corrected by reconciliation with the accrual system"
+
+type ReconciliationRepository interface {
+	GetFinalizedOrders(ctx context.Context, sampleSize int) ([]entity.Order, error)
+	GetCorrectionByOrder(ctx context.Context, orderNumber string) (float64, error)
+	SaveMismatch(ctx context.Context, mismatch entity.ReconciliationMismatch) error
+}
+
+type ReconciliationService struct {
+	txManager                   TxManager
+	reconciliationRepository    ReconciliationRepository
+	providers                   *AccrualProviderRegistry
+	userLocker                  UserLocker
+	balanceGetter               BalanceGetter
+	balanceAdjustmentRepository BalanceAdjustmentRepository
+	outboxRecorder              OutboxRecorder
+	clock                       Clock
+	log                         *zap.Logger
+}
+
+func NewReconciliationService(
+	txManager TxManager,
+	reconciliationRepository ReconciliationRepository,
+	providers *AccrualProviderRegistry,
+	userLocker UserLocker,
+	balanceGetter BalanceGetter,
+	balanceAdjustmentRepository BalanceAdjustmentRepository,
+	outboxRecorder OutboxRecorder,
+	clock Clock,
+	log *zap.Logger,
+) *ReconciliationService {
+	return &ReconciliationService{
+		txManager:                   txManager,
+		reconciliationRepository:    reconciliationRepository,
+		providers:                   providers,
+		userLocker:                  userLocker,
+		balanceGetter:               balanceGetter,
+		balanceAdjustmentRepository: balanceAdjustmentRepository,
+		outboxRecorder:              outboxRecorder,
+		clock:                       clock,
+		log:                         log,
+	}
+}
+
+func (s *ReconciliationService) Reconcile(ctx context.Context, sampleSize int, applyCorrections bool) (*business.ReconciliationReport, error) {
+	report := &business.ReconciliationReport{
+		RunID:     uuid.New(),
+		StartedAt: s.clock.Now(),
+	}
+
+	orders, err := s.reconciliationRepository.GetFinalizedOrders(ctx, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Starting reconciliation of finalized orders ...",
+		zap.String("run_id", report.RunID.String()),
+		zap.Int("orders", len(orders)),
+	)
+
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		mismatch, err := s.reconcileOrder(ctx, report.RunID, order, applyCorrections)
+		if err != nil {
+			report.Failed++
+			s.log.Error("Something went wrong on reconciling order",
+				zap.String("order", order.Number),
+				zap.String("error", err.Error()),
+			)
+			continue
+		}
+
+		report.Checked++
+		if mismatch == nil {
+			continue
+		}
+
+		report.Mismatches = append(report.Mismatches, *mismatch)
+		if mismatch.Corrected {
+			report.Corrected++
+		}
+	}
+
+	report.FinishedAt = s.clock.Now()
+	s.log.Info("Reconciliation has been finished",
+		zap.String("run_id", report.RunID.String()),
+		zap.Int("checked", report.Checked),
+		zap.Int("failed", report.Failed),
+		zap.Int("mismatches", len(report.Mismatches)),
+		zap.Int("corrected", report.Corrected),
+	)
+
+	return report, nil
+}
+
+func (s *ReconciliationService) reconcileOrder(ctx context.Context, runID uuid.UUID, order entity.Order, applyCorrections bool) (*entity.ReconciliationMismatch, error) {
+	accrualResponse, err := s.providers.Resolve(order.Number).client.GetAccrualData(ctx, order.Number)
+	if err != nil {
+		return nil, err
+	}
+	if accrualResponse == nil {
+		return nil, errs.New(errs.OrderStatusClient, "order is not registered in Accrual service", nil)
+	}
+
+	remoteStatus, err := OrderStatusFromAccrual(accrualResponse.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteAccrual := 0.0
+	if remoteStatus == entity.OrderProcessedStatus {
+		remoteAccrual = accrualResponse.Accrual
+	}
+
+	var mismatch *entity.ReconciliationMismatch
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		correction, err := s.reconciliationRepository.GetCorrectionByOrder(ctx, order.Number)
+		if err != nil {
+			return err
+		}
+
+		difference := roundPoints(remoteAccrual - (order.Accrual + correction))
+		if difference == 0 && remoteStatus == order.Status {
+			return nil
+		}
+
+		mismatch = &entity.ReconciliationMismatch{
+			ID:            uuid.New(),
+			RunID:         runID,
+			OrderID:       order.ID,
+			OrderNumber:   order.Number,
+			UserID:        order.UserID,
+			StoredStatus:  order.Status,
+			StoredAccrual: order.Accrual + correction,
+			RemoteStatus:  remoteStatus,
+			RemoteAccrual: remoteAccrual,
+			Difference:    difference,
+			DetectedAt:    s.clock.Now(),
+		}
+
+		if applyCorrections && difference != 0 && (remoteStatus == entity.OrderProcessedStatus || remoteStatus == entity.OrderInvalidStatus) {
+			err := s.applyCorrection(ctx, *mismatch)
+			var appErr *errs.AppError
+			if errors.As(err, &appErr) && appErr.Code == errs.NotEnoughAccrual {
+				s.log.Warn("Accrual correction is not applied, balance is not enough",
+					zap.String("order", order.Number),
+					zap.Float64("difference", difference),
+				)
+			} else if err != nil {
+				return err
+			} else {
+				mismatch.Corrected = true
+			}
+		}
+
+		return s.reconciliationRepository.SaveMismatch(ctx, *mismatch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mismatch, nil
+}
+
+func (s *ReconciliationService) applyCorrection(ctx context.Context, mismatch entity.ReconciliationMismatch) error {
+	exists, err := s.userLocker.LockByID(ctx, mismatch.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errs.New(errs.UserNotFound, "user not found", nil)
+	}
+
+	if mismatch.Difference < 0 {
+		balance, err := s.balanceGetter.GetBalance(ctx, mismatch.UserID)
+		if err != nil {
+			return err
+		}
+
+		if balance.Total+mismatch.Difference < 0 {
+			return errs.New(errs.NotEnoughAccrual, "correction would make the balance negative", nil)
+		}
+	}
+
+	savedAdjustment, err := s.balanceAdjustmentRepository.Save(ctx, entity.BalanceAdjustment{
+		ID:        uuid.New(),
+		UserID:    mismatch.UserID,
+		Kind:      entity.AdjustmentKindReconciliation,
+		Amount:    mismatch.Difference,
+		Reason:    accrualCorrectedReason,
+		Reference: mismatch.OrderNumber,
+		CreatedAt: mismatch.DetectedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("Accrual corrected",
+		zap.String("order", mismatch.OrderNumber),
+		zap.String("user_id", mismatch.UserID.String()),
+		zap.Float64("amount", mismatch.Difference))
+
+	return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventBalanceAdjusted, mismatch.UserID, toBalanceAdjustmentViewModel(*savedAdjustment))
+}
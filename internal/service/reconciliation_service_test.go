@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) GetFinalizedOrders(ctx context.Context, sampleSize int) ([]entity.Order, error) {
+	args := m.Called(ctx, sampleSize)
+	return args.Get(0).([]entity.Order), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) GetCorrectionByOrder(ctx context.Context, orderNumber string) (float64, error) {
+	args := m.Called(ctx, orderNumber)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) SaveMismatch(ctx context.Context, mismatch entity.ReconciliationMismatch) error {
+	args := m.Called(ctx, mismatch)
+	return args.Error(0)
+}
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 5, 9, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	order := entity.Order{
+		ID:      uuid.New(),
+		Number:  "79927398713",
+		UserID:  userID,
+		Status:  entity.OrderProcessedStatus,
+		Accrual: 100,
+	}
+
+	type deps struct {
+		repository           *MockReconciliationRepository
+		accrualClient        *MockAccrualClient
+		userLocker           *MockUserLocker
+		balanceGetter        *MockBalanceGetter
+		adjustmentRepository *MockBalanceAdjustmentRepository
+		outboxRecorder       *MockOutboxRecorder
+	}
+
+	newService := func(t *testing.T) (*ReconciliationService, deps) {
+		d := deps{
+			repository:           new(MockReconciliationRepository),
+			accrualClient:        new(MockAccrualClient),
+			userLocker:           new(MockUserLocker),
+			balanceGetter:        new(MockBalanceGetter),
+			adjustmentRepository: new(MockBalanceAdjustmentRepository),
+			outboxRecorder:       new(MockOutboxRecorder),
+		}
+		svc := NewReconciliationService(
+			passThroughTxManager{},
+			d.repository,
+			NewAccrualProviderRegistry(d.accrualClient),
+			d.userLocker,
+			d.balanceGetter,
+			d.adjustmentRepository,
+			d.outboxRecorder,
+			fixedClock{now: now},
+			zaptest.NewLogger(t),
+		)
+		return svc, d
+	}
+
+	t.Run("matching order is not reported", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 10).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSED", Accrual: 100}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(0.0, nil)
+
+		report, err := svc.Reconcile(ctx, 10, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Checked)
+		assert.Empty(t, report.Mismatches)
+		d.repository.AssertNotCalled(t, "SaveMismatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("difference is only reported without corrections", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 0).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSED", Accrual: 120.5}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(0.0, nil)
+		d.repository.On("SaveMismatch", ctx, mock.MatchedBy(func(m entity.ReconciliationMismatch) bool {
+			return m.OrderNumber == order.Number && m.Difference == 20.5 && !m.Corrected
+		})).Return(nil)
+
+		report, err := svc.Reconcile(ctx, 0, false)
+
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches, 1)
+		assert.Equal(t, 0, report.Corrected)
+		d.adjustmentRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		d.repository.AssertExpectations(t)
+	})
+
+	t.Run("difference is credited as reconciliation adjustment", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 0).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSED", Accrual: 120.5}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(0.0, nil)
+		d.userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		d.adjustmentRepository.On("Save", ctx, mock.MatchedBy(func(a entity.BalanceAdjustment) bool {
+			return a.Kind == entity.AdjustmentKindReconciliation && a.Amount == 20.5 && a.Reference == order.Number
+		})).Return(nil)
+		d.outboxRecorder.On("Save", ctx, mock.Anything).Return(nil)
+		d.repository.On("SaveMismatch", ctx, mock.MatchedBy(func(m entity.ReconciliationMismatch) bool {
+			return m.Corrected
+		})).Return(nil)
+
+		report, err := svc.Reconcile(ctx, 0, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Corrected)
+		d.adjustmentRepository.AssertExpectations(t)
+		d.outboxRecorder.AssertExpectations(t)
+		d.balanceGetter.AssertNotCalled(t, "GetBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("earlier correction is counted as credited", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 0).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSED", Accrual: 120.5}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(20.5, nil)
+
+		report, err := svc.Reconcile(ctx, 0, true)
+
+		require.NoError(t, err)
+		assert.Empty(t, report.Mismatches)
+		d.adjustmentRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("order still processing remotely is not corrected", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 0).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSING"}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(0.0, nil)
+		d.repository.On("SaveMismatch", ctx, mock.MatchedBy(func(m entity.ReconciliationMismatch) bool {
+			return m.RemoteStatus == entity.OrderProcessingStatus && m.Difference == -100 && !m.Corrected
+		})).Return(nil)
+
+		report, err := svc.Reconcile(ctx, 0, true)
+
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches, 1)
+		assert.Equal(t, 0, report.Corrected)
+		d.userLocker.AssertNotCalled(t, "LockByID", mock.Anything, mock.Anything)
+		d.repository.AssertExpectations(t)
+	})
+
+	t.Run("debit exceeding balance is left uncorrected", func(t *testing.T) {
+		svc, d := newService(t)
+		d.repository.On("GetFinalizedOrders", ctx, 0).Return([]entity.Order{order}, nil)
+		d.accrualClient.On("GetAccrualData", ctx, order.Number).Return(&view.AccrualResponse{Order: order.Number, Status: "PROCESSED", Accrual: 40}, nil)
+		d.repository.On("GetCorrectionByOrder", ctx, order.Number).Return(0.0, nil)
+		d.userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		d.balanceGetter.On("GetBalance", ctx, userID).Return(&business.Balance{Total: 30}, nil)
+		d.repository.On("SaveMismatch", ctx, mock.MatchedBy(func(m entity.ReconciliationMismatch) bool {
+			return m.Difference == -60 && !m.Corrected
+		})).Return(nil)
+
+		report, err := svc.Reconcile(ctx, 0, true)
+
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches, 1)
+		assert.Equal(t, 0, report.Corrected)
+		d.adjustmentRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		d.repository.AssertExpectations(t)
+	})
+}
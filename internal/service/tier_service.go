@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+type UserTierRepository interface {
+	GetAccruedByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error)
+	FindByUser(ctx context.Context, userID uuid.UUID) (*entity.UserTier, error)
+	GetAll(ctx context.Context) ([]entity.UserTier, error)
+	Save(ctx context.Context, userTier entity.UserTier) error
+	SaveHistory(ctx context.Context, history entity.UserTierHistory) error
+}
+
+type TierService struct {
+	txManager          TxManager
+	userTierRepository UserTierRepository
+	outboxRecorder     OutboxRecorder
+	clock              Clock
+	window             time.Duration
+	levels             []business.TierLevel
+	log                *zap.Logger
+}
+
+func NewTierService(
+	txManager TxManager,
+	userTierRepository UserTierRepository,
+	outboxRecorder OutboxRecorder,
+	clock Clock,
+	window time.Duration,
+	levels []business.TierLevel,
+	log *zap.Logger,
+) *TierService {
+	sortedLevels := make([]business.TierLevel, len(levels))
+	copy(sortedLevels, levels)
+	sort.SliceStable(sortedLevels, func(i, j int) bool {
+		return sortedLevels[i].Threshold < sortedLevels[j].Threshold
+	})
+
+	return &TierService{
+		txManager:          txManager,
+		userTierRepository: userTierRepository,
+		outboxRecorder:     outboxRecorder,
+		clock:              clock,
+		window:             window,
+		levels:             sortedLevels,
+		log:                log,
+	}
+}
+
+func (s *TierService) RefreshTiers(ctx context.Context) {
+	now := s.clock.Now()
+	userTiers, err := s.userTierRepository.GetAll(ctx)
+	if err != nil {
+		s.log.Error("Failed to get user tiers", zap.Error(err))
+		return
+	}
+
+	for _, userTier := range userTiers {
+		accrued, err := s.userTierRepository.GetAccruedByUserSince(ctx, userTier.UserID, s.windowStart(now))
+		if err != nil {
+			s.log.Error("Failed to get accrued points for tier",
+				zap.String("user_id", userTier.UserID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		if s.tierFor(accrued) == userTier.Tier {
+			continue
+		}
+
+		err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+			return s.RecalculateTier(ctx, userTier.UserID, now)
+		})
+		if err != nil {
+			s.log.Error("Failed to recalculate tier",
+				zap.String("user_id", userTier.UserID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *TierService) RecalculateTier(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	accrued, err := s.userTierRepository.GetAccruedByUserSince(ctx, userID, s.windowStart(now))
+	if err != nil {
+		return err
+	}
+
+	currentTier, err := s.userTierRepository.FindByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	tier := s.tierFor(accrued)
+	userTier := entity.UserTier{
+		UserID:    userID,
+		Tier:      tier,
+		Accrued:   accrued,
+		TierSince: now,
+		UpdatedAt: now,
+	}
+
+	if currentTier != nil && currentTier.Tier == tier {
+		userTier.TierSince = currentTier.TierSince
+		return s.userTierRepository.Save(ctx, userTier)
+	}
+
+	if err := s.userTierRepository.Save(ctx, userTier); err != nil {
+		return err
+	}
+
+	var previousTier string
+	if currentTier != nil {
+		previousTier = currentTier.Tier
+	}
+
+	err = s.userTierRepository.SaveHistory(ctx, entity.UserTierHistory{
+		ID:           uuid.New(),
+		UserID:       userID,
+		PreviousTier: previousTier,
+		Tier:         tier,
+		Accrued:      accrued,
+		ChangedAt:    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	data := view.TierChangeViewModel{
+		PreviousTier: previousTier,
+		Tier:         tier,
+		Accrued:      accrued,
+		ChangedAt:    now,
+	}
+	return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventTierChanged, userID, data)
+}
+
+func (s *TierService) GetProfile(ctx context.Context, userID uuid.UUID) (*business.Profile, error) {
+	profile := &business.Profile{}
+
+	userTier, err := s.userTierRepository.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accrued, err := s.userTierRepository.GetAccruedByUserSince(ctx, userID, s.windowStart(s.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Tier = s.tierFor(accrued)
+	profile.Accrued = accrued
+	if userTier != nil && userTier.Tier == profile.Tier {
+		profile.TierSince = &userTier.TierSince
+	}
+
+	for _, level := range s.levels {
+		if level.Threshold > profile.Accrued {
+			profile.NextTier = level.Name
+			profile.ToNextTier = level.Threshold - profile.Accrued
+			break
+		}
+	}
+
+	return profile, nil
+}
+
+func (s *TierService) tierFor(accrued float64) string {
+	tier := ""
+	for _, level := range s.levels {
+		if accrued >= level.Threshold {
+			tier = level.Name
+		}
+	}
+	return tier
+}
+
+func (s *TierService) windowStart(now time.Time) time.Time {
+	if s.window <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-s.window)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type MockUserTierRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserTierRepository) GetAccruedByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockUserTierRepository) FindByUser(ctx context.Context, userID uuid.UUID) (*entity.UserTier, error) {
+	args := m.Called(ctx, userID)
+	userTier, _ := args.Get(0).(*entity.UserTier)
+	return userTier, args.Error(1)
+}
+
+func (m *MockUserTierRepository) GetAll(ctx context.Context) ([]entity.UserTier, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.UserTier), args.Error(1)
+}
+
+func (m *MockUserTierRepository) Save(ctx context.Context, userTier entity.UserTier) error {
+	args := m.Called(ctx, userTier)
+	return args.Error(0)
+}
+
+func (m *MockUserTierRepository) SaveHistory(ctx context.Context, history entity.UserTierHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+var testTierLevels = []business.TierLevel{
+	{Name: entity.TierGold, Threshold: 5000},
+	{Name: entity.TierBronze, Threshold: 0},
+	{Name: entity.TierSilver, Threshold: 1000},
+}
+
+func TestTierService_RecalculateTier(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 1, 9, 0, 0, 0, time.UTC)
+	window := 365 * 24 * time.Hour
+	userID := uuid.New()
+
+	t.Run("records promotion", func(t *testing.T) {
+		repository := new(MockUserTierRepository)
+		outboxRecorder := new(MockOutboxRecorder)
+
+		repository.On("GetAccruedByUserSince", ctx, userID, now.Add(-window)).Return(1200.0, nil)
+		repository.On("FindByUser", ctx, userID).Return(&entity.UserTier{UserID: userID, Tier: entity.TierBronze}, nil)
+		repository.On("Save", ctx, entity.UserTier{
+			UserID:    userID,
+			Tier:      entity.TierSilver,
+			Accrued:   1200,
+			TierSince: now,
+			UpdatedAt: now,
+		}).Return(nil)
+		repository.On("SaveHistory", ctx, mock.MatchedBy(func(history entity.UserTierHistory) bool {
+			return history.PreviousTier == entity.TierBronze &&
+				history.Tier == entity.TierSilver &&
+				history.ChangedAt.Equal(now)
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventTierChanged && event.UserID == userID
+		})).Return(nil)
+
+		service := NewTierService(passThroughTxManager{}, repository, outboxRecorder, fixedClock{now: now}, window, testTierLevels, zaptest.NewLogger(t))
+		err := service.RecalculateTier(ctx, userID, now)
+
+		require.NoError(t, err)
+		repository.AssertExpectations(t)
+		outboxRecorder.AssertExpectations(t)
+	})
+
+	t.Run("keeps tier start when tier is unchanged", func(t *testing.T) {
+		repository := new(MockUserTierRepository)
+		tierSince := now.AddDate(0, -2, 0)
+
+		repository.On("GetAccruedByUserSince", ctx, userID, now.Add(-window)).Return(6000.0, nil)
+		repository.On("FindByUser", ctx, userID).Return(&entity.UserTier{UserID: userID, Tier: entity.TierGold, TierSince: tierSince}, nil)
+		repository.On("Save", ctx, mock.MatchedBy(func(userTier entity.UserTier) bool {
+			return userTier.Tier == entity.TierGold && userTier.TierSince.Equal(tierSince) && userTier.Accrued == 6000
+		})).Return(nil)
+
+		service := NewTierService(passThroughTxManager{}, repository, nil, fixedClock{now: now}, window, testTierLevels, zaptest.NewLogger(t))
+		err := service.RecalculateTier(ctx, userID, now)
+
+		require.NoError(t, err)
+		repository.AssertNotCalled(t, "SaveHistory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTierService_GetProfile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 1, 9, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	repository := new(MockUserTierRepository)
+	repository.On("FindByUser", ctx, userID).Return(nil, nil)
+	repository.On("GetAccruedByUserSince", ctx, userID, time.Time{}).Return(400.0, nil)
+
+	service := NewTierService(passThroughTxManager{}, repository, nil, fixedClock{now: now}, 0, testTierLevels, zaptest.NewLogger(t))
+	profile, err := service.GetProfile(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.TierBronze, profile.Tier)
+	assert.Nil(t, profile.TierSince)
+	assert.Equal(t, entity.TierSilver, profile.NextTier)
+	assert.Equal(t, 600.0, profile.ToNextTier)
+}
+
+func TestTierService_GetProfile_RecomputesAccruedWithinWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 1, 9, 0, 0, 0, time.UTC)
+	window := 365 * 24 * time.Hour
+	userID := uuid.New()
+
+	repository := new(MockUserTierRepository)
+	repository.On("FindByUser", ctx, userID).Return(&entity.UserTier{UserID: userID, Tier: entity.TierSilver, Accrued: 1200, TierSince: now.AddDate(-1, 0, 0)}, nil)
+	repository.On("GetAccruedByUserSince", ctx, userID, now.Add(-window)).Return(300.0, nil)
+
+	service := NewTierService(passThroughTxManager{}, repository, nil, fixedClock{now: now}, window, testTierLevels, zaptest.NewLogger(t))
+	profile, err := service.GetProfile(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.TierBronze, profile.Tier)
+	assert.Equal(t, 300.0, profile.Accrued)
+	assert.Nil(t, profile.TierSince)
+	assert.Equal(t, 700.0, profile.ToNextTier)
+}
+
+func TestTierService_RefreshTiers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 1, 9, 0, 0, 0, time.UTC)
+	window := 365 * 24 * time.Hour
+	downgradedID := uuid.New()
+	unchangedID := uuid.New()
+
+	repository := new(MockUserTierRepository)
+	outboxRecorder := new(MockOutboxRecorder)
+
+	silver := &entity.UserTier{UserID: downgradedID, Tier: entity.TierSilver, Accrued: 1200, TierSince: now.AddDate(-1, -1, 0)}
+	repository.On("GetAll", ctx).Return([]entity.UserTier{
+		*silver,
+		{UserID: unchangedID, Tier: entity.TierBronze, Accrued: 100},
+	}, nil)
+	repository.On("GetAccruedByUserSince", ctx, downgradedID, now.Add(-window)).Return(200.0, nil)
+	repository.On("GetAccruedByUserSince", ctx, unchangedID, now.Add(-window)).Return(100.0, nil)
+	repository.On("FindByUser", ctx, downgradedID).Return(silver, nil)
+	repository.On("Save", ctx, entity.UserTier{
+		UserID:    downgradedID,
+		Tier:      entity.TierBronze,
+		Accrued:   200,
+		TierSince: now,
+		UpdatedAt: now,
+	}).Return(nil)
+	repository.On("SaveHistory", ctx, mock.MatchedBy(func(history entity.UserTierHistory) bool {
+		return history.UserID == downgradedID &&
+			history.PreviousTier == entity.TierSilver &&
+			history.Tier == entity.TierBronze &&
+			history.ChangedAt.Equal(now)
+	})).Return(nil)
+	outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+		return event.EventType == entity.OutboxEventTierChanged && event.UserID == downgradedID
+	})).Return(nil)
+
+	service := NewTierService(passThroughTxManager{}, repository, outboxRecorder, fixedClock{now: now}, window, testTierLevels, zaptest.NewLogger(t))
+	service.RefreshTiers(ctx)
+
+	repository.AssertExpectations(t)
+	outboxRecorder.AssertExpectations(t)
+	repository.AssertNotCalled(t, "FindByUser", ctx, unchangedID)
+}
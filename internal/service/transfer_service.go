@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"strings"
+	"time"
+)
+
+type TransferRepository interface {
+	Save(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error)
+	GetTotalSentByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error)
+}
+
+type UserIDFinder interface {
+	FindIDByLogin(ctx context.Context, login string) (uuid.UUID, error)
+}
+
+type TransferService struct {
+	transferRepository TransferRepository
+	userIDFinder       UserIDFinder
+	userLocker         UserLocker
+	balanceGetter      BalanceGetter
+	outboxRecorder     OutboxRecorder
+	txManager          TxManager
+	clock              Clock
+	dailyLimit         float64
+}
+
+func NewTransferService(
+	transferRepository TransferRepository,
+	userIDFinder UserIDFinder,
+	userLocker UserLocker,
+	balanceGetter BalanceGetter,
+	outboxRecorder OutboxRecorder,
+	txManager TxManager,
+	clock Clock,
+	dailyLimit float64,
+) *TransferService {
+	return &TransferService{
+		transferRepository: transferRepository,
+		userIDFinder:       userIDFinder,
+		userLocker:         userLocker,
+		balanceGetter:      balanceGetter,
+		outboxRecorder:     outboxRecorder,
+		txManager:          txManager,
+		clock:              clock,
+		dailyLimit:         dailyLimit,
+	}
+}
+
+func (s *TransferService) AddTransfer(ctx context.Context, transferCreateCommand command.TransferCreateCommand, senderID uuid.UUID, senderLogin string) (*entity.Transfer, error) {
+	if transferCreateCommand.Sum <= 0 {
+		return nil, errs.New(errs.InvalidTransfer, "transfer sum must be positive", nil)
+	}
+
+	recipientLogin := strings.TrimSpace(transferCreateCommand.Recipient)
+	var savedTransfer *entity.Transfer
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		recipientID, err := s.userIDFinder.FindIDByLogin(ctx, recipientLogin)
+		if err != nil {
+			return err
+		}
+
+		if recipientID == uuid.Nil {
+			return errs.New(errs.UserNotFound, "recipient not found", nil)
+		}
+
+		if recipientID == senderID {
+			return errs.New(errs.InvalidTransfer, "transfer to yourself is not allowed", nil)
+		}
+
+		if err := s.lockUsers(ctx, senderID, recipientID); err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		if s.dailyLimit > 0 {
+			sentToday, err := s.transferRepository.GetTotalSentByUserSince(ctx, senderID, startOfDay(now))
+			if err != nil {
+				return err
+			}
+
+			if sentToday+transferCreateCommand.Sum > s.dailyLimit {
+				return errs.New(errs.TransferLimitExceeded, "transfer daily limit exceeded", nil)
+			}
+		}
+
+		balance, err := s.balanceGetter.GetBalance(ctx, senderID)
+		if err != nil {
+			return errs.New(errs.Generic, "failed to get balance", err)
+		}
+
+		if transferCreateCommand.Sum > balance.Total {
+			return errs.New(errs.NotEnoughAccrual, "not enough accrual", nil)
+		}
+
+		rawTransfer := entity.Transfer{
+			ID:          uuid.New(),
+			SenderID:    senderID,
+			RecipientID: recipientID,
+			Sum:         transferCreateCommand.Sum,
+			CreatedAt:   now,
+		}
+
+		savedTransfer, err = s.transferRepository.Save(ctx, rawTransfer)
+		if err != nil {
+			return err
+		}
+
+		data := view.TransferViewModel{
+			Sender:      senderLogin,
+			Recipient:   recipientLogin,
+			Sum:         savedTransfer.Sum,
+			ProcessedAt: savedTransfer.CreatedAt,
+		}
+		if err := recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventTransferSent, senderID, data); err != nil {
+			return err
+		}
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventTransferReceived, recipientID, data)
+	})
+
+	return savedTransfer, err
+}
+
+func (s *TransferService) lockUsers(ctx context.Context, firstID uuid.UUID, secondID uuid.UUID) error {
+	if bytes.Compare(firstID[:], secondID[:]) > 0 {
+		firstID, secondID = secondID, firstID
+	}
+
+	for _, userID := range []uuid.UUID{firstID, secondID} {
+		exists, err := s.userLocker.LockByID(ctx, userID)
+		if err != nil {
+			return errs.New(errs.Generic, "failed to lock user balance", err)
+		}
+
+		if !exists {
+			return errs.New(errs.UserNotFound, "user not found", nil)
+		}
+	}
+
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferRepository) Save(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	args := m.Called(ctx, transfer)
+	return &transfer, args.Error(0)
+}
+
+func (m *MockTransferRepository) GetTotalSentByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (float64, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+type MockUserIDFinder struct {
+	mock.Mock
+}
+
+func (m *MockUserIDFinder) FindIDByLogin(ctx context.Context, login string) (uuid.UUID, error) {
+	args := m.Called(ctx, login)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func TestTransferService_AddTransfer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 28, 15, 30, 0, 0, time.UTC)
+	midnight := time.Date(2025, time.July, 28, 0, 0, 0, 0, time.UTC)
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	transferCommand := command.TransferCreateCommand{Recipient: "bob", Sum: 100}
+
+	t.Run("moves points to recipient and notifies both sides", func(t *testing.T) {
+		transferRepository := new(MockTransferRepository)
+		userIDFinder := new(MockUserIDFinder)
+		userLocker := new(MockUserLocker)
+		balanceGetter := new(MockBalanceGetter)
+		outboxRecorder := new(MockOutboxRecorder)
+
+		var lockOrder []uuid.UUID
+		userIDFinder.On("FindIDByLogin", ctx, "bob").Return(recipientID, nil)
+		userLocker.On("LockByID", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			lockOrder = append(lockOrder, args.Get(1).(uuid.UUID))
+		}).Return(true, nil)
+		transferRepository.On("GetTotalSentByUserSince", ctx, senderID, midnight).Return(850.0, nil)
+		balanceGetter.On("GetBalance", ctx, senderID).Return(&business.Balance{Total: 300}, nil)
+		transferRepository.On("Save", ctx, mock.MatchedBy(func(transfer entity.Transfer) bool {
+			return transfer.SenderID == senderID && transfer.RecipientID == recipientID && transfer.Sum == 100
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventTransferSent && event.UserID == senderID
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventTransferReceived && event.UserID == recipientID
+		})).Return(nil)
+
+		service := NewTransferService(transferRepository, userIDFinder, userLocker, balanceGetter, outboxRecorder,
+			passThroughTxManager{}, fixedClock{now: now}, 1000)
+		transfer, err := service.AddTransfer(ctx, transferCommand, senderID, "alice")
+
+		require.NoError(t, err)
+		assert.Equal(t, now, transfer.CreatedAt)
+		require.Len(t, lockOrder, 2)
+		assert.Negative(t, bytes.Compare(lockOrder[0][:], lockOrder[1][:]))
+		transferRepository.AssertExpectations(t)
+		outboxRecorder.AssertExpectations(t)
+	})
+
+	t.Run("rejects transfer over daily limit", func(t *testing.T) {
+		transferRepository := new(MockTransferRepository)
+		userIDFinder := new(MockUserIDFinder)
+		userLocker := new(MockUserLocker)
+
+		userIDFinder.On("FindIDByLogin", ctx, "bob").Return(recipientID, nil)
+		userLocker.On("LockByID", ctx, mock.Anything).Return(true, nil)
+		transferRepository.On("GetTotalSentByUserSince", ctx, senderID, midnight).Return(950.0, nil)
+
+		service := NewTransferService(transferRepository, userIDFinder, userLocker, nil, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 1000)
+		_, err := service.AddTransfer(ctx, transferCommand, senderID, "alice")
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.TransferLimitExceeded, appErr.Code)
+		transferRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects transfer above sender balance", func(t *testing.T) {
+		transferRepository := new(MockTransferRepository)
+		userIDFinder := new(MockUserIDFinder)
+		userLocker := new(MockUserLocker)
+		balanceGetter := new(MockBalanceGetter)
+
+		userIDFinder.On("FindIDByLogin", ctx, "bob").Return(recipientID, nil)
+		userLocker.On("LockByID", ctx, mock.Anything).Return(true, nil)
+		balanceGetter.On("GetBalance", ctx, senderID).Return(&business.Balance{Total: 99.99}, nil)
+
+		service := NewTransferService(transferRepository, userIDFinder, userLocker, balanceGetter, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 0)
+		_, err := service.AddTransfer(ctx, transferCommand, senderID, "alice")
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.NotEnoughAccrual, appErr.Code)
+		transferRepository.AssertNotCalled(t, "GetTotalSentByUserSince", mock.Anything, mock.Anything, mock.Anything)
+		transferRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects transfer to yourself", func(t *testing.T) {
+		userIDFinder := new(MockUserIDFinder)
+		userIDFinder.On("FindIDByLogin", ctx, "bob").Return(senderID, nil)
+
+		service := NewTransferService(nil, userIDFinder, nil, nil, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 1000)
+		_, err := service.AddTransfer(ctx, transferCommand, senderID, "bob")
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.InvalidTransfer, appErr.Code)
+	})
+
+	t.Run("rejects unknown recipient", func(t *testing.T) {
+		userIDFinder := new(MockUserIDFinder)
+		userIDFinder.On("FindIDByLogin", ctx, "bob").Return(uuid.Nil, nil)
+
+		service := NewTransferService(nil, userIDFinder, nil, nil, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 1000)
+		_, err := service.AddTransfer(ctx, transferCommand, senderID, "alice")
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.UserNotFound, appErr.Code)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"time"
 )
@@ -12,6 +13,10 @@ import (
 type UserRepository interface {
 	Save(ctx context.Context, userData entity.UserData) error
 	FindByLogin(ctx context.Context, login string) (*entity.UserData, error)
+	GetAll(ctx context.Context) ([]entity.UserData, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) (bool, error)
+	UpdateRoleByLogin(ctx context.Context, login string, role string) (bool, error)
+	FindRoleByID(ctx context.Context, id uuid.UUID) (string, error)
 }
 
 type PasswordManager interface {
@@ -41,6 +46,7 @@ func (s *UserService) AddUser(ctx context.Context, userCreateCommand command.Use
 		ID:        uuid.New(),
 		Login:     userCreateCommand.Login,
 		Password:  hashedPassword,
+		Role:      entity.UserRoleUser,
 		CreatedAt: time.Now(),
 	}
 
@@ -63,3 +69,53 @@ func (s *UserService) FindByLoginAndPassword(ctx context.Context, login string,
 
 	return userData, nil
 }
+
+func (s *UserService) GetUsers(ctx context.Context) ([]entity.UserData, error) {
+	return s.userRepository.GetAll(ctx)
+}
+
+func (s *UserService) GetUserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	role, err := s.userRepository.FindRoleByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if role == "" {
+		return "", errs.New(errs.UserNotFound, "user not found", nil)
+	}
+
+	return role, nil
+}
+
+func (s *UserService) SetUserRole(ctx context.Context, userID uuid.UUID, role string) error {
+	if role != entity.UserRoleUser && role != entity.UserRoleAdmin {
+		return errs.New(errs.InvalidRole, "unknown role", nil)
+	}
+
+	updated, err := s.userRepository.UpdateRole(ctx, userID, role)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return errs.New(errs.UserNotFound, "user not found", nil)
+	}
+
+	return nil
+}
+
+func (s *UserService) GrantAdminRole(ctx context.Context, logins []string) ([]string, error) {
+	var granted []string
+	for _, login := range logins {
+		updated, err := s.userRepository.UpdateRoleByLogin(ctx, login, entity.UserRoleAdmin)
+		if err != nil {
+			return granted, err
+		}
+
+		if updated {
+			granted = append(granted, login)
+		}
+	}
+
+	return granted, nil
+}
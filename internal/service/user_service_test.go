@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"testing"
 	"time"
@@ -30,6 +31,26 @@ func (m *MockUserRepository) FindByLogin(ctx context.Context, login string) (*en
 	return args.Get(0).(*entity.UserData), args.Error(1)
 }
 
+func (m *MockUserRepository) GetAll(ctx context.Context) ([]entity.UserData, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.UserData), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role string) (bool, error) {
+	args := m.Called(ctx, id, role)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateRoleByLogin(ctx context.Context, login string, role string) (bool, error) {
+	args := m.Called(ctx, login, role)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) FindRoleByID(ctx context.Context, id uuid.UUID) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
 type MockPasswordService struct {
 	mock.Mock
 }
@@ -52,7 +73,7 @@ func TestUserService_AddUser_Success(t *testing.T) {
 	passwordService.On("Hash", "password123").Return("hashed123", nil)
 
 	repo.On("Save", mock.Anything, mock.MatchedBy(func(user entity.UserData) bool {
-		return user.Login == "testuser" && user.Password == "hashed123"
+		return user.Login == "testuser" && user.Password == "hashed123" && user.Role == entity.UserRoleUser
 	})).Return(nil)
 
 	userService := NewUserService(repo, passwordService)
@@ -159,3 +180,68 @@ func TestUserService_FindByLoginAndPassword_InvalidPassword(t *testing.T) {
 	repo.AssertExpectations(t)
 	passwordService.AssertExpectations(t)
 }
+
+func TestUserService_SetUserRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("updates role of existing user", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		userID := uuid.New()
+		repo.On("UpdateRole", ctx, userID, entity.UserRoleAdmin).Return(true, nil)
+
+		err := NewUserService(repo, nil).SetUserRole(ctx, userID, entity.UserRoleAdmin)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects unknown role", func(t *testing.T) {
+		repo := new(MockUserRepository)
+
+		err := NewUserService(repo, nil).SetUserRole(ctx, uuid.New(), "ROOT")
+
+		var appErr *errs.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.InvalidRole, appErr.Code)
+		repo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for unknown user", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		userID := uuid.New()
+		repo.On("UpdateRole", ctx, userID, entity.UserRoleUser).Return(false, nil)
+
+		err := NewUserService(repo, nil).SetUserRole(ctx, userID, entity.UserRoleUser)
+
+		var appErr *errs.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.UserNotFound, appErr.Code)
+	})
+}
+
+func TestUserService_GetUserRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns stored role", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		userID := uuid.New()
+		repo.On("FindRoleByID", ctx, userID).Return(entity.UserRoleAdmin, nil)
+
+		role, err := NewUserService(repo, nil).GetUserRole(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.UserRoleAdmin, role)
+	})
+
+	t.Run("fails for unknown user", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		userID := uuid.New()
+		repo.On("FindRoleByID", ctx, userID).Return("", nil)
+
+		_, err := NewUserService(repo, nil).GetUserRole(ctx, userID)
+
+		var appErr *errs.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.UserNotFound, appErr.Code)
+	})
+}
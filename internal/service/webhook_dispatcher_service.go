@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	webhookDispatchBatchSize = 100
+	webhookDeliveryLease     = time.Minute
+)
+
+var webhookEventTypes = map[string]struct{}{
+	entity.OutboxEventOrderProcessed:   {},
+	entity.OutboxEventOrderInvalid:     {},
+	entity.OutboxEventWithdrawCreated:  {},
+	entity.OutboxEventWithdrawReversed: {},
+	entity.OutboxEventTransferReceived: {},
+	entity.OutboxEventTierChanged:      {},
+}
+
+type OutboxDispatchRepository interface {
+	GetUndispatched(ctx context.Context, limit int) ([]entity.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, id uuid.UUID, dispatchedAt time.Time) error
+}
+
+type WebhookFinder interface {
+	GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery entity.WebhookDelivery) error
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]business.WebhookDeliveryTask, error)
+	UpdateResult(ctx context.Context, delivery entity.WebhookDelivery) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, task business.WebhookDeliveryTask) error
+}
+
+type WebhookDispatcherService struct {
+	txManager                 TxManager
+	outboxRepository          OutboxDispatchRepository
+	webhookFinder             WebhookFinder
+	webhookDeliveryRepository WebhookDeliveryRepository
+	webhookSender             WebhookSender
+	maxAttempts               int
+	baseBackoff               time.Duration
+	maxBackoff                time.Duration
+	log                       *zap.Logger
+}
+
+func NewWebhookDispatcherService(
+	txManager TxManager,
+	outboxRepository OutboxDispatchRepository,
+	webhookFinder WebhookFinder,
+	webhookDeliveryRepository WebhookDeliveryRepository,
+	webhookSender WebhookSender,
+	maxAttempts int,
+	baseBackoff time.Duration,
+	maxBackoff time.Duration,
+	log *zap.Logger,
+) *WebhookDispatcherService {
+	return &WebhookDispatcherService{
+		txManager:                 txManager,
+		outboxRepository:          outboxRepository,
+		webhookFinder:             webhookFinder,
+		webhookDeliveryRepository: webhookDeliveryRepository,
+		webhookSender:             webhookSender,
+		maxAttempts:               maxAttempts,
+		baseBackoff:               baseBackoff,
+		maxBackoff:                maxBackoff,
+		log:                       log,
+	}
+}
+
+func (s *WebhookDispatcherService) Dispatch(ctx context.Context) {
+	if err := s.fanOut(ctx); err != nil {
+		s.log.Error("Something went wrong on scheduling webhook deliveries", zap.Error(err))
+	}
+
+	s.deliver(ctx)
+}
+
+func (s *WebhookDispatcherService) fanOut(ctx context.Context) error {
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		events, err := s.outboxRepository.GetUndispatched(ctx, webhookDispatchBatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, event := range events {
+			if _, ok := webhookEventTypes[event.EventType]; ok {
+				if err := s.scheduleDeliveries(ctx, event, now); err != nil {
+					return err
+				}
+			}
+
+			if err := s.outboxRepository.MarkDispatched(ctx, event.ID, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *WebhookDispatcherService) scheduleDeliveries(ctx context.Context, event entity.OutboxEvent, now time.Time) error {
+	webhooks, err := s.webhookFinder.GetAllByUser(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery := entity.WebhookDelivery{
+			ID:            uuid.New(),
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			Status:        entity.WebhookDeliveryPendingStatus,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := s.webhookDeliveryRepository.Save(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookDispatcherService) deliver(ctx context.Context) {
+	now := time.Now()
+	tasks, err := s.webhookDeliveryRepository.ClaimDue(ctx, now, now.Add(webhookDeliveryLease), webhookDispatchBatchSize)
+	if err != nil {
+		s.log.Error("Something went wrong on claiming webhook deliveries", zap.Error(err))
+		return
+	}
+
+	deliveredCount := 0
+	for _, task := range tasks {
+		sendErr := s.webhookSender.Send(ctx, task)
+
+		result := s.deliveryResult(task, sendErr, time.Now())
+		if err := s.webhookDeliveryRepository.UpdateResult(ctx, result); err != nil {
+			s.log.Error("Something went wrong on saving webhook delivery result",
+				zap.String("delivery_id", task.DeliveryID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if sendErr != nil {
+			s.log.Warn("Webhook delivery attempt failed",
+				zap.String("delivery_id", task.DeliveryID.String()),
+				zap.Int("attempt", result.Attempts),
+				zap.String("status", result.Status),
+				zap.Error(sendErr),
+			)
+			continue
+		}
+		deliveredCount++
+	}
+
+	if len(tasks) > 0 {
+		s.log.Info("Webhook deliveries have been processed",
+			zap.Int("claimed", len(tasks)),
+			zap.Int("delivered", deliveredCount),
+		)
+	}
+}
+
+func (s *WebhookDispatcherService) deliveryResult(task business.WebhookDeliveryTask, sendErr error, now time.Time) entity.WebhookDelivery {
+	result := entity.WebhookDelivery{
+		ID:       task.DeliveryID,
+		Attempts: task.Attempts + 1,
+	}
+
+	switch {
+	case sendErr == nil:
+		result.Status = entity.WebhookDeliveryDeliveredStatus
+		result.NextAttemptAt = now
+		result.DeliveredAt = &now
+	case result.Attempts >= s.maxAttempts:
+		result.Status = entity.WebhookDeliveryFailedStatus
+		result.NextAttemptAt = now
+		result.LastError = sendErr.Error()
+	default:
+		result.Status = entity.WebhookDeliveryPendingStatus
+		result.NextAttemptAt = now.Add(webhookBackoff(result.Attempts, s.baseBackoff, s.maxBackoff))
+		result.LastError = sendErr.Error()
+	}
+
+	return result
+}
+
+func webhookBackoff(attempt int, base time.Duration, limit time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= limit {
+			return limit
+		}
+	}
+	return min(backoff, limit)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) Save(ctx context.Context, delivery entity.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]business.WebhookDeliveryTask, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	return args.Get(0).([]business.WebhookDeliveryTask), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) UpdateResult(ctx context.Context, delivery entity.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, task business.WebhookDeliveryTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	base := 10 * time.Second
+	limit := time.Minute
+
+	assert.Equal(t, 10*time.Second, webhookBackoff(1, base, limit))
+	assert.Equal(t, 20*time.Second, webhookBackoff(2, base, limit))
+	assert.Equal(t, 40*time.Second, webhookBackoff(3, base, limit))
+	assert.Equal(t, time.Minute, webhookBackoff(4, base, limit))
+	assert.Equal(t, time.Minute, webhookBackoff(50, base, limit))
+}
+
+func TestWebhookDispatcherService_Deliver(t *testing.T) {
+	ctx := context.Background()
+	logger := zaptest.NewLogger(t)
+
+	newService := func(repo *MockWebhookDeliveryRepository, sender *MockWebhookSender) *WebhookDispatcherService {
+		return NewWebhookDispatcherService(nil, nil, nil, repo, sender, 3, 10*time.Second, time.Hour, logger)
+	}
+
+	t.Run("marks successful delivery as delivered", func(t *testing.T) {
+		repo := new(MockWebhookDeliveryRepository)
+		sender := new(MockWebhookSender)
+
+		task := business.WebhookDeliveryTask{DeliveryID: uuid.New(), Attempts: 0}
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, webhookDispatchBatchSize).Return([]business.WebhookDeliveryTask{task}, nil)
+		sender.On("Send", ctx, task).Return(nil)
+		repo.On("UpdateResult", ctx, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return delivery.ID == task.DeliveryID &&
+				delivery.Status == entity.WebhookDeliveryDeliveredStatus &&
+				delivery.Attempts == 1 &&
+				delivery.DeliveredAt != nil
+		})).Return(nil)
+
+		newService(repo, sender).deliver(ctx)
+
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("reschedules failed delivery with backoff", func(t *testing.T) {
+		repo := new(MockWebhookDeliveryRepository)
+		sender := new(MockWebhookSender)
+
+		task := business.WebhookDeliveryTask{DeliveryID: uuid.New(), Attempts: 1}
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, webhookDispatchBatchSize).Return([]business.WebhookDeliveryTask{task}, nil)
+		sender.On("Send", ctx, task).Return(errors.New("receiver is down"))
+		repo.On("UpdateResult", ctx, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return delivery.Status == entity.WebhookDeliveryPendingStatus &&
+				delivery.Attempts == 2 &&
+				delivery.LastError == "receiver is down" &&
+				time.Until(delivery.NextAttemptAt) > 15*time.Second
+		})).Return(nil)
+
+		newService(repo, sender).deliver(ctx)
+
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		repo := new(MockWebhookDeliveryRepository)
+		sender := new(MockWebhookSender)
+
+		task := business.WebhookDeliveryTask{DeliveryID: uuid.New(), Attempts: 2}
+		repo.On("ClaimDue", ctx, mock.Anything, mock.Anything, webhookDispatchBatchSize).Return([]business.WebhookDeliveryTask{task}, nil)
+		sender.On("Send", ctx, task).Return(errors.New("receiver is down"))
+		repo.On("UpdateResult", ctx, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+			return delivery.Status == entity.WebhookDeliveryFailedStatus && delivery.Attempts == 3
+		})).Return(nil)
+
+		newService(repo, sender).deliver(ctx)
+
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/infrastructure/netguard"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"net/url"
+	"time"
+)
+
+const webhookSecretSize = 32
+
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error)
+	UpdateURL(ctx context.Context, id uuid.UUID, userID uuid.UUID, url string, updatedAt time.Time) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error)
+	FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Webhook, error)
+	GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error)
+}
+
+type WebhookService struct {
+	webhookRepository WebhookRepository
+}
+
+func NewWebhookService(webhookRepository WebhookRepository) *WebhookService {
+	return &WebhookService{
+		webhookRepository: webhookRepository,
+	}
+}
+
+func (s *WebhookService) AddWebhook(ctx context.Context, webhookCreateCommand command.WebhookCreateCommand, authUserID uuid.UUID) (*entity.Webhook, error) {
+	if err := validateWebhookURL(ctx, webhookCreateCommand.URL); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errs.New(errs.Generic, "failed to generate webhook secret", err)
+	}
+
+	now := time.Now()
+	rawWebhook := entity.Webhook{
+		ID:        uuid.New(),
+		UserID:    authUserID,
+		URL:       webhookCreateCommand.URL,
+		Secret:    hex.EncodeToString(secret),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return s.webhookRepository.Save(ctx, rawWebhook)
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id uuid.UUID, webhookUpdateCommand command.WebhookUpdateCommand, authUserID uuid.UUID) (*entity.Webhook, error) {
+	if err := validateWebhookURL(ctx, webhookUpdateCommand.URL); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.webhookRepository.UpdateURL(ctx, id, authUserID, webhookUpdateCommand.URL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, errs.New(errs.WebhookNotFound, "webhook not found", nil)
+	}
+
+	return s.GetWebhook(ctx, id, authUserID)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID, authUserID uuid.UUID) error {
+	deleted, err := s.webhookRepository.Delete(ctx, id, authUserID)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return errs.New(errs.WebhookNotFound, "webhook not found", nil)
+	}
+
+	return nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id uuid.UUID, authUserID uuid.UUID) (*entity.Webhook, error) {
+	webhook, err := s.webhookRepository.FindByID(ctx, id, authUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, errs.New(errs.WebhookNotFound, "webhook not found", nil)
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhooks(ctx context.Context, authUserID uuid.UUID) ([]entity.Webhook, error) {
+	return s.webhookRepository.GetAllByUser(ctx, authUserID)
+}
+
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	parsedURL, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return errs.New(errs.InvalidWebhookURL, "invalid webhook url", err)
+	}
+
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errs.New(errs.InvalidWebhookURL, "webhook url must be an absolute http(s) url", nil)
+	}
+
+	if err := netguard.CheckHost(ctx, parsedURL.Hostname()); err != nil {
+		return errs.New(errs.InvalidWebhookURL, "webhook url must point to a public host", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Save(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	return &webhook, args.Error(0)
+}
+
+func (m *MockWebhookRepository) UpdateURL(ctx context.Context, id uuid.UUID, userID uuid.UUID, url string, updatedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, userID, url, updatedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Webhook, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func TestWebhookService_AddWebhook(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("saves public url", func(t *testing.T) {
+		repository := new(MockWebhookRepository)
+		repository.On("Save", ctx, mock.Anything).Return(nil)
+
+		webhook, err := NewWebhookService(repository).AddWebhook(ctx, command.WebhookCreateCommand{URL: "https://93.184.216.34/hook"}, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://93.184.216.34/hook", webhook.URL)
+		assert.NotEmpty(t, webhook.Secret)
+	})
+
+	for _, url := range []string{
+		"ftp://93.184.216.34/hook",
+		"http://localhost:8080/hook",
+		"http://127.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.10/hook",
+		"http://0.0.0.0/hook",
+		"http://metadata.google.internal/hook",
+	} {
+		t.Run("rejects "+url, func(t *testing.T) {
+			repository := new(MockWebhookRepository)
+
+			_, err := NewWebhookService(repository).AddWebhook(ctx, command.WebhookCreateCommand{URL: url}, userID)
+
+			var appErr *errs.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, errs.InvalidWebhookURL, appErr.Code)
+			repository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"github.com/ShiraazMoollatjie/goluhn"
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"go.uber.org/zap"
+	"time"
+)
+
+type WithdrawHoldRepository interface {
+	Save(ctx context.Context, hold entity.WithdrawHold) (*entity.WithdrawHold, error)
+	FindActive(ctx context.Context, orderNumber string) (*entity.WithdrawHold, error)
+	GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error)
+	Resolve(ctx context.Context, id uuid.UUID, status string, resolvedAt time.Time) (bool, error)
+	ExpireDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+type WithdrawHoldService struct {
+	withdrawHoldRepository WithdrawHoldRepository
+	withdrawRepository     WithdrawRepository
+	balanceGetter          BalanceGetter
+	userLocker             UserLocker
+	outboxRecorder         OutboxRecorder
+	txManager              TxManager
+	clock                  Clock
+	holdTTL                time.Duration
+	log                    *zap.Logger
+}
+
+func NewWithdrawHoldService(
+	withdrawHoldRepository WithdrawHoldRepository,
+	withdrawRepository WithdrawRepository,
+	balanceGetter BalanceGetter,
+	userLocker UserLocker,
+	outboxRecorder OutboxRecorder,
+	txManager TxManager,
+	clock Clock,
+	holdTTL time.Duration,
+	log *zap.Logger,
+) *WithdrawHoldService {
+	return &WithdrawHoldService{
+		withdrawHoldRepository: withdrawHoldRepository,
+		withdrawRepository:     withdrawRepository,
+		balanceGetter:          balanceGetter,
+		userLocker:             userLocker,
+		outboxRecorder:         outboxRecorder,
+		txManager:              txManager,
+		clock:                  clock,
+		holdTTL:                holdTTL,
+		log:                    log,
+	}
+}
+
+func (s *WithdrawHoldService) AddHold(ctx context.Context, withdrawCreateCommand command.WithdrawCreateCommand, authUserID uuid.UUID) (*entity.WithdrawHold, error) {
+	if err := goluhn.Validate(withdrawCreateCommand.Order); err != nil {
+		return nil, errs.New(errs.InvalidOrderNumber, "invalid order number", err)
+	}
+
+	var savedHold *entity.WithdrawHold
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.userLocker.LockByID(ctx, authUserID); err != nil {
+			return errs.New(errs.Generic, "failed to lock user balance", err)
+		}
+
+		existingWithdraw, err := s.withdrawRepository.FindByOrderNumber(ctx, withdrawCreateCommand.Order)
+		if err != nil {
+			return err
+		}
+
+		existingHold, err := s.withdrawHoldRepository.FindActive(ctx, withdrawCreateCommand.Order)
+		if err != nil {
+			return err
+		}
+
+		if existingWithdraw != nil || existingHold != nil {
+			return errs.New(errs.WithdrawAlreadyExists, "withdraw for this order already exists", nil)
+		}
+
+		balance, err := s.balanceGetter.GetBalance(ctx, authUserID)
+		if err != nil {
+			return errs.New(errs.Generic, "failed to get balance", err)
+		}
+
+		if withdrawCreateCommand.Sum > balance.Total {
+			return errs.New(errs.NotEnoughAccrual, "not enough accrual", nil)
+		}
+
+		now := s.clock.Now()
+		rawHold := entity.WithdrawHold{
+			ID:          uuid.New(),
+			UserID:      authUserID,
+			OrderNumber: withdrawCreateCommand.Order,
+			Sum:         withdrawCreateCommand.Sum,
+			Status:      entity.WithdrawHoldStatusActive,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.holdTTL),
+		}
+
+		savedHold, err = s.withdrawHoldRepository.Save(ctx, rawHold)
+		return err
+	})
+
+	return savedHold, err
+}
+
+func (s *WithdrawHoldService) CaptureHold(ctx context.Context, orderNumber string, authUserID uuid.UUID) (*entity.Withdraw, error) {
+	var savedWithdraw *entity.Withdraw
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		hold, err := s.lockActiveHold(ctx, orderNumber, authUserID)
+		if err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		if !hold.ExpiresAt.After(now) {
+			return errs.New(errs.WithdrawHoldExpired, "withdraw hold expired", nil)
+		}
+
+		if err := s.resolveHold(ctx, hold, entity.WithdrawHoldStatusCaptured, now); err != nil {
+			return err
+		}
+
+		rawWithdraw := entity.Withdraw{
+			ID:          uuid.New(),
+			OrderNumber: hold.OrderNumber,
+			UserID:      hold.UserID,
+			Status:      entity.WithdrawStatusCompleted,
+			CreatedAt:   now,
+			Sum:         hold.Sum,
+		}
+
+		savedWithdraw, err = s.withdrawRepository.Save(ctx, rawWithdraw)
+		if err != nil {
+			return err
+		}
+
+		data := view.WithdrawViewModel{
+			OrderNumber: savedWithdraw.OrderNumber,
+			Sum:         savedWithdraw.Sum,
+			Status:      savedWithdraw.Status,
+			ProcessedAt: savedWithdraw.CreatedAt,
+		}
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventWithdrawCreated, authUserID, data)
+	})
+
+	return savedWithdraw, err
+}
+
+func (s *WithdrawHoldService) ReleaseHold(ctx context.Context, orderNumber string, authUserID uuid.UUID) (*entity.WithdrawHold, error) {
+	var releasedHold *entity.WithdrawHold
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		hold, err := s.lockActiveHold(ctx, orderNumber, authUserID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.resolveHold(ctx, hold, entity.WithdrawHoldStatusReleased, s.clock.Now()); err != nil {
+			return err
+		}
+
+		releasedHold = hold
+		return nil
+	})
+
+	return releasedHold, err
+}
+
+func (s *WithdrawHoldService) GetActiveHolds(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error) {
+	return s.withdrawHoldRepository.GetActiveByUser(ctx, userID)
+}
+
+func (s *WithdrawHoldService) ExpireHolds(ctx context.Context) {
+	expired, err := s.withdrawHoldRepository.ExpireDue(ctx, s.clock.Now())
+	if err != nil {
+		s.log.Error("Failed to expire withdraw holds", zap.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		s.log.Info("Withdraw holds expired", zap.Int64("count", expired))
+	}
+}
+
+func (s *WithdrawHoldService) lockActiveHold(ctx context.Context, orderNumber string, authUserID uuid.UUID) (*entity.WithdrawHold, error) {
+	if _, err := s.userLocker.LockByID(ctx, authUserID); err != nil {
+		return nil, errs.New(errs.Generic, "failed to lock user balance", err)
+	}
+
+	hold, err := s.withdrawHoldRepository.FindActive(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold == nil || hold.UserID != authUserID {
+		return nil, errs.New(errs.WithdrawHoldNotFound, "withdraw hold not found", nil)
+	}
+
+	return hold, nil
+}
+
+func (s *WithdrawHoldService) resolveHold(ctx context.Context, hold *entity.WithdrawHold, status string, resolvedAt time.Time) error {
+	resolved, err := s.withdrawHoldRepository.Resolve(ctx, hold.ID, status, resolvedAt)
+	if err != nil {
+		return err
+	}
+
+	if !resolved {
+		return errs.New(errs.WithdrawHoldNotFound, "withdraw hold not found", nil)
+	}
+
+	hold.Status = status
+	hold.ResolvedAt = &resolvedAt
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type MockWithdrawHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockWithdrawHoldRepository) Save(ctx context.Context, hold entity.WithdrawHold) (*entity.WithdrawHold, error) {
+	args := m.Called(ctx, hold)
+	return &hold, args.Error(0)
+}
+
+func (m *MockWithdrawHoldRepository) FindActive(ctx context.Context, orderNumber string) (*entity.WithdrawHold, error) {
+	args := m.Called(ctx, orderNumber)
+	hold, _ := args.Get(0).(*entity.WithdrawHold)
+	return hold, args.Error(1)
+}
+
+func (m *MockWithdrawHoldRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID) ([]entity.WithdrawHold, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.WithdrawHold), args.Error(1)
+}
+
+func (m *MockWithdrawHoldRepository) Resolve(ctx context.Context, id uuid.UUID, status string, resolvedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, status, resolvedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWithdrawHoldRepository) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestWithdrawHoldService_AddHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 24, 10, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	orderNumber := "79927398713"
+
+	t.Run("reserves points until ttl", func(t *testing.T) {
+		holdRepository := new(MockWithdrawHoldRepository)
+		withdrawRepository := new(MockWithdrawRepository)
+		balanceGetter := new(MockBalanceGetter)
+		userLocker := new(MockUserLocker)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(nil, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(nil, nil)
+		balanceGetter.On("GetBalance", ctx, userID).Return(&business.Balance{Total: 100}, nil)
+		holdRepository.On("Save", ctx, mock.MatchedBy(func(hold entity.WithdrawHold) bool {
+			return hold.UserID == userID &&
+				hold.OrderNumber == orderNumber &&
+				hold.Sum == 80 &&
+				hold.Status == entity.WithdrawHoldStatusActive &&
+				hold.ExpiresAt.Equal(now.Add(15*time.Minute))
+		})).Return(nil)
+
+		service := NewWithdrawHoldService(holdRepository, withdrawRepository, balanceGetter, userLocker, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+		hold, err := service.AddHold(ctx, command.WithdrawCreateCommand{Order: orderNumber, Sum: 80}, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WithdrawHoldStatusActive, hold.Status)
+		holdRepository.AssertExpectations(t)
+	})
+
+	t.Run("rejects hold above available balance", func(t *testing.T) {
+		holdRepository := new(MockWithdrawHoldRepository)
+		withdrawRepository := new(MockWithdrawRepository)
+		balanceGetter := new(MockBalanceGetter)
+		userLocker := new(MockUserLocker)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(nil, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(nil, nil)
+		balanceGetter.On("GetBalance", ctx, userID).Return(&business.Balance{Total: 50, Held: 50}, nil)
+
+		service := NewWithdrawHoldService(holdRepository, withdrawRepository, balanceGetter, userLocker, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+		_, err := service.AddHold(ctx, command.WithdrawCreateCommand{Order: orderNumber, Sum: 80}, userID)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.NotEnoughAccrual, appErr.Code)
+		holdRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWithdrawHoldService_CaptureHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 24, 10, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	orderNumber := "79927398713"
+
+	newHold := func(expiresAt time.Time) *entity.WithdrawHold {
+		return &entity.WithdrawHold{
+			ID:          uuid.New(),
+			UserID:      userID,
+			OrderNumber: orderNumber,
+			Sum:         80,
+			Status:      entity.WithdrawHoldStatusActive,
+			CreatedAt:   now.Add(-time.Minute),
+			ExpiresAt:   expiresAt,
+		}
+	}
+
+	t.Run("turns active hold into completed withdrawal", func(t *testing.T) {
+		holdRepository := new(MockWithdrawHoldRepository)
+		withdrawRepository := new(MockWithdrawRepository)
+		userLocker := new(MockUserLocker)
+		outboxRecorder := new(MockOutboxRecorder)
+		hold := newHold(now.Add(time.Minute))
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(hold, nil)
+		holdRepository.On("Resolve", ctx, hold.ID, entity.WithdrawHoldStatusCaptured, now).Return(true, nil)
+		withdrawRepository.On("Save", ctx, mock.MatchedBy(func(withdraw entity.Withdraw) bool {
+			return withdraw.OrderNumber == orderNumber &&
+				withdraw.UserID == userID &&
+				withdraw.Sum == 80 &&
+				withdraw.Status == entity.WithdrawStatusCompleted
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.Anything).Return(nil)
+
+		service := NewWithdrawHoldService(holdRepository, withdrawRepository, nil, userLocker, outboxRecorder,
+			passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+		withdraw, err := service.CaptureHold(ctx, orderNumber, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 80.0, withdraw.Sum)
+		withdrawRepository.AssertExpectations(t)
+		holdRepository.AssertExpectations(t)
+	})
+
+	t.Run("rejects capture after ttl", func(t *testing.T) {
+		holdRepository := new(MockWithdrawHoldRepository)
+		withdrawRepository := new(MockWithdrawRepository)
+		userLocker := new(MockUserLocker)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(newHold(now), nil)
+
+		service := NewWithdrawHoldService(holdRepository, withdrawRepository, nil, userLocker, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+		_, err := service.CaptureHold(ctx, orderNumber, userID)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawHoldExpired, appErr.Code)
+		withdrawRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("does not expose holds of another user", func(t *testing.T) {
+		holdRepository := new(MockWithdrawHoldRepository)
+		userLocker := new(MockUserLocker)
+		anotherUserID := uuid.New()
+
+		userLocker.On("LockByID", ctx, anotherUserID).Return(true, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(newHold(now.Add(time.Minute)), nil)
+
+		service := NewWithdrawHoldService(holdRepository, nil, nil, userLocker, nil,
+			passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+		_, err := service.ReleaseHold(ctx, orderNumber, anotherUserID)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawHoldNotFound, appErr.Code)
+		holdRepository.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWithdrawHoldService_ExpireHolds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 24, 10, 0, 0, 0, time.UTC)
+
+	holdRepository := new(MockWithdrawHoldRepository)
+	holdRepository.On("ExpireDue", ctx, now).Return(int64(2), nil)
+
+	service := NewWithdrawHoldService(holdRepository, nil, nil, nil, nil,
+		passThroughTxManager{}, fixedClock{now: now}, 15*time.Minute, zaptest.NewLogger(t))
+	service.ExpireHolds(ctx)
+
+	holdRepository.AssertExpectations(t)
+}
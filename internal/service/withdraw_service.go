@@ -2,11 +2,12 @@ package service
 
 import (
 	"context"
+	"github.com/ShiraazMoollatjie/goluhn"
 	"github.com/google/uuid"
 	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/dto/view"
 	"github.com/ruslanDantsov/gophermart/internal/errs"
 	"github.com/ruslanDantsov/gophermart/internal/handler/middleware"
-	"github.com/ruslanDantsov/gophermart/internal/infrastructure/storage/postgre"
 	"github.com/ruslanDantsov/gophermart/internal/model/business"
 	"github.com/ruslanDantsov/gophermart/internal/model/entity"
 	"time"
@@ -15,52 +16,83 @@ import (
 type WithdrawRepository interface {
 	Save(ctx context.Context, withdraw entity.Withdraw) (*entity.Withdraw, error)
 	GetAllWithdrawDetailsByUser(ctx context.Context, userID uuid.UUID) ([]business.WithdrawDetail, error)
+	FindByOrderNumber(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error)
+	Reverse(ctx context.Context, id uuid.UUID, reversedAt time.Time) (bool, error)
 }
 
-type OrderCreator interface {
-	AddOrder(ctx context.Context, orderCreateCommand command.OrderCreateCommand) (*entity.Order, error)
+type ActiveHoldFinder interface {
+	FindActive(ctx context.Context, orderNumber string) (*entity.WithdrawHold, error)
 }
 
 type WithdrawService struct {
-	orderCreatorService         OrderCreator
-	withdrawRepository          WithdrawRepository
-	accrualAggregatorRepository AccrualAggregatorRepository
-	storage                     *postgre.PostgreStorage
+	withdrawRepository WithdrawRepository
+	activeHoldFinder   ActiveHoldFinder
+	balanceGetter      BalanceGetter
+	userLocker         UserLocker
+	outboxRecorder     OutboxRecorder
+	txManager          TxManager
 }
 
-func NewWithdrawService(orderCreatorService OrderCreator, withdrawRepository WithdrawRepository, accrualAggregatorRepository AccrualAggregatorRepository, storage *postgre.PostgreStorage) *WithdrawService {
+func NewWithdrawService(
+	withdrawRepository WithdrawRepository,
+	activeHoldFinder ActiveHoldFinder,
+	balanceGetter BalanceGetter,
+	userLocker UserLocker,
+	outboxRecorder OutboxRecorder,
+	txManager TxManager,
+) *WithdrawService {
 	return &WithdrawService{
-		orderCreatorService:         orderCreatorService,
-		withdrawRepository:          withdrawRepository,
-		accrualAggregatorRepository: accrualAggregatorRepository,
-		storage:                     storage,
+		withdrawRepository: withdrawRepository,
+		activeHoldFinder:   activeHoldFinder,
+		balanceGetter:      balanceGetter,
+		userLocker:         userLocker,
+		outboxRecorder:     outboxRecorder,
+		txManager:          txManager,
 	}
 }
 
 func (s *WithdrawService) AddWithdraw(ctx context.Context, withdrawCreateCommand command.WithdrawCreateCommand, authUserID uuid.UUID) (*entity.Withdraw, error) {
+	if err := goluhn.Validate(withdrawCreateCommand.Order); err != nil {
+		return nil, errs.New(errs.InvalidOrderNumber, "invalid order number", err)
+	}
+
 	var savedWithdraw *entity.Withdraw
 
-	err := s.storage.WithTx(ctx, func(ctx context.Context) error {
-		totalAccrual, err := s.accrualAggregatorRepository.GetTotalAccrualByUser(ctx, authUserID)
-		if err != nil {
-			return errs.New(errs.Generic, "failed to get total accrual", err)
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.userLocker.LockByID(ctx, authUserID); err != nil {
+			return errs.New(errs.Generic, "failed to lock user balance", err)
 		}
 
-		if withdrawCreateCommand.Sum > totalAccrual {
-			return errs.New(errs.NotEnoughAccrual, "not enough accrual", nil)
+		existingWithdraw, err := s.withdrawRepository.FindByOrderNumber(ctx, withdrawCreateCommand.Order)
+		if err != nil {
+			return err
 		}
 
-		orderCreateCommand := command.OrderCreateCommand{Number: withdrawCreateCommand.Order}
-		order, err := s.orderCreatorService.AddOrder(ctx, orderCreateCommand)
+		existingHold, err := s.activeHoldFinder.FindActive(ctx, withdrawCreateCommand.Order)
 		if err != nil {
 			return err
 		}
 
+		if existingWithdraw != nil || existingHold != nil {
+			return errs.New(errs.WithdrawAlreadyExists, "withdraw for this order already exists", nil)
+		}
+
+		balance, err := s.balanceGetter.GetBalance(ctx, authUserID)
+		if err != nil {
+			return errs.New(errs.Generic, "failed to get balance", err)
+		}
+
+		if withdrawCreateCommand.Sum > balance.Total {
+			return errs.New(errs.NotEnoughAccrual, "not enough accrual", nil)
+		}
+
 		rawWithdraw := entity.Withdraw{
-			ID:        uuid.New(),
-			OrderID:   order.ID,
-			CreatedAt: time.Now(),
-			Sum:       withdrawCreateCommand.Sum,
+			ID:          uuid.New(),
+			OrderNumber: withdrawCreateCommand.Order,
+			UserID:      authUserID,
+			Status:      entity.WithdrawStatusCompleted,
+			CreatedAt:   time.Now(),
+			Sum:         withdrawCreateCommand.Sum,
 		}
 
 		savedWithdraw, err = s.withdrawRepository.Save(ctx, rawWithdraw)
@@ -68,14 +100,72 @@ func (s *WithdrawService) AddWithdraw(ctx context.Context, withdrawCreateCommand
 			return err
 		}
 
-		return nil
+		data := view.WithdrawViewModel{
+			OrderNumber: savedWithdraw.OrderNumber,
+			Sum:         savedWithdraw.Sum,
+			Status:      savedWithdraw.Status,
+			ProcessedAt: savedWithdraw.CreatedAt,
+		}
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventWithdrawCreated, authUserID, data)
 	})
 
 	return savedWithdraw, err
 }
 
+func (s *WithdrawService) ReverseWithdraw(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error) {
+	var reversedWithdraw *business.WithdrawDetail
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		withdraw, err := s.withdrawRepository.FindByOrderNumber(ctx, orderNumber)
+		if err != nil {
+			return err
+		}
+
+		if withdraw == nil {
+			return errs.New(errs.WithdrawNotFound, "withdraw not found", nil)
+		}
+
+		if withdraw.Status == entity.WithdrawStatusReversed {
+			return errs.New(errs.WithdrawAlreadyReversed, "withdraw is already reversed", nil)
+		}
+
+		if _, err := s.userLocker.LockByID(ctx, withdraw.UserID); err != nil {
+			return errs.New(errs.Generic, "failed to lock user balance", err)
+		}
+
+		reversedAt := time.Now()
+		reversed, err := s.withdrawRepository.Reverse(ctx, withdraw.ID, reversedAt)
+		if err != nil {
+			return err
+		}
+
+		if !reversed {
+			return errs.New(errs.WithdrawAlreadyReversed, "withdraw is already reversed", nil)
+		}
+
+		withdraw.Status = entity.WithdrawStatusReversed
+		withdraw.ReversedAt = &reversedAt
+		reversedWithdraw = withdraw
+
+		data := view.WithdrawViewModel{
+			OrderNumber: withdraw.OrderNumber,
+			Sum:         withdraw.Sum,
+			Status:      withdraw.Status,
+			ProcessedAt: withdraw.CreatedAt,
+			ReversedAt:  withdraw.ReversedAt,
+		}
+		return recordOutboxEvent(ctx, s.outboxRecorder, entity.OutboxEventWithdrawReversed, withdraw.UserID, data)
+	})
+
+	return reversedWithdraw, err
+}
+
 func (s *WithdrawService) GetWithdrawDetails(ctx context.Context) ([]business.WithdrawDetail, error) {
 	userID := ctx.Value(middleware.CtxUserIDKey{}).(uuid.UUID)
+	return s.GetWithdrawDetailsByUser(ctx, userID)
+}
+
+func (s *WithdrawService) GetWithdrawDetailsByUser(ctx context.Context, userID uuid.UUID) ([]business.WithdrawDetail, error) {
 	withdraws, err := s.withdrawRepository.GetAllWithdrawDetailsByUser(ctx, userID)
 	if err != nil {
 		return nil, err
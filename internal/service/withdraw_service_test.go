@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruslanDantsov/gophermart/internal/dto/command"
+	"github.com/ruslanDantsov/gophermart/internal/errs"
+	"github.com/ruslanDantsov/gophermart/internal/model/business"
+	"github.com/ruslanDantsov/gophermart/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWithdrawRepository struct {
+	mock.Mock
+}
+
+func (m *MockWithdrawRepository) Save(ctx context.Context, withdraw entity.Withdraw) (*entity.Withdraw, error) {
+	args := m.Called(ctx, withdraw)
+	return &withdraw, args.Error(0)
+}
+
+func (m *MockWithdrawRepository) GetAllWithdrawDetailsByUser(ctx context.Context, userID uuid.UUID) ([]business.WithdrawDetail, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]business.WithdrawDetail), args.Error(1)
+}
+
+func (m *MockWithdrawRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*business.WithdrawDetail, error) {
+	args := m.Called(ctx, orderNumber)
+	withdraw, _ := args.Get(0).(*business.WithdrawDetail)
+	return withdraw, args.Error(1)
+}
+
+func (m *MockWithdrawRepository) Reverse(ctx context.Context, id uuid.UUID, reversedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, reversedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockBalanceGetter struct {
+	mock.Mock
+}
+
+func (m *MockBalanceGetter) GetBalance(ctx context.Context, userID uuid.UUID) (*business.Balance, error) {
+	args := m.Called(ctx, userID)
+	balance, _ := args.Get(0).(*business.Balance)
+	return balance, args.Error(1)
+}
+
+func TestWithdrawService_AddWithdraw(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orderNumber := "79927398713"
+
+	t.Run("stores withdrawal under its own order number", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		holdRepository := new(MockWithdrawHoldRepository)
+		balanceGetter := new(MockBalanceGetter)
+		userLocker := new(MockUserLocker)
+		outboxRecorder := new(MockOutboxRecorder)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(nil, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(nil, nil)
+		balanceGetter.On("GetBalance", ctx, userID).Return(&business.Balance{Total: 500}, nil)
+		withdrawRepository.On("Save", ctx, mock.MatchedBy(func(withdraw entity.Withdraw) bool {
+			return withdraw.OrderNumber == orderNumber &&
+				withdraw.UserID == userID &&
+				withdraw.Sum == 120 &&
+				withdraw.Status == entity.WithdrawStatusCompleted
+		})).Return(nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventWithdrawCreated && event.UserID == userID
+		})).Return(nil)
+
+		service := NewWithdrawService(withdrawRepository, holdRepository, balanceGetter, userLocker, outboxRecorder, passThroughTxManager{})
+		withdraw, err := service.AddWithdraw(ctx, command.WithdrawCreateCommand{Order: orderNumber, Sum: 120}, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, orderNumber, withdraw.OrderNumber)
+		withdrawRepository.AssertExpectations(t)
+		outboxRecorder.AssertExpectations(t)
+	})
+
+	t.Run("rejects order number failing the Luhn check", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, nil, nil, passThroughTxManager{})
+		withdraw, err := service.AddWithdraw(ctx, command.WithdrawCreateCommand{Order: "79927398710", Sum: 120}, userID)
+
+		assert.Nil(t, withdraw)
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.InvalidOrderNumber, appErr.Code)
+		withdrawRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a second withdrawal for the same order number", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		holdRepository := new(MockWithdrawHoldRepository)
+		userLocker := new(MockUserLocker)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(&business.WithdrawDetail{OrderNumber: orderNumber}, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(nil, nil)
+
+		service := NewWithdrawService(withdrawRepository, holdRepository, nil, userLocker, nil, passThroughTxManager{})
+		_, err := service.AddWithdraw(ctx, command.WithdrawCreateCommand{Order: orderNumber, Sum: 120}, userID)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawAlreadyExists, appErr.Code)
+		withdrawRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects withdrawal above current balance", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		holdRepository := new(MockWithdrawHoldRepository)
+		balanceGetter := new(MockBalanceGetter)
+		userLocker := new(MockUserLocker)
+
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(nil, nil)
+		holdRepository.On("FindActive", ctx, orderNumber).Return(nil, nil)
+		balanceGetter.On("GetBalance", ctx, userID).Return(&business.Balance{Total: 100}, nil)
+
+		service := NewWithdrawService(withdrawRepository, holdRepository, balanceGetter, userLocker, nil, passThroughTxManager{})
+		_, err := service.AddWithdraw(ctx, command.WithdrawCreateCommand{Order: orderNumber, Sum: 120}, userID)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.NotEnoughAccrual, appErr.Code)
+		withdrawRepository.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWithdrawService_ReverseWithdraw(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orderNumber := "79927398713"
+
+	t.Run("reverses completed withdrawal", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		userLocker := new(MockUserLocker)
+		outboxRecorder := new(MockOutboxRecorder)
+		withdraw := &business.WithdrawDetail{
+			ID:          uuid.New(),
+			UserID:      userID,
+			OrderNumber: orderNumber,
+			Sum:         120,
+			Status:      entity.WithdrawStatusCompleted,
+		}
+
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(withdraw, nil)
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("Reverse", ctx, withdraw.ID, mock.Anything).Return(true, nil)
+		outboxRecorder.On("Save", ctx, mock.MatchedBy(func(event entity.OutboxEvent) bool {
+			return event.EventType == entity.OutboxEventWithdrawReversed && event.UserID == userID
+		})).Return(nil)
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, userLocker, outboxRecorder, passThroughTxManager{})
+		reversed, err := service.ReverseWithdraw(ctx, orderNumber)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WithdrawStatusReversed, reversed.Status)
+		assert.NotNil(t, reversed.ReversedAt)
+		outboxRecorder.AssertExpectations(t)
+	})
+
+	t.Run("rejects already reversed withdrawal", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(&business.WithdrawDetail{
+			UserID: userID,
+			Status: entity.WithdrawStatusReversed,
+		}, nil)
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, nil, nil, passThroughTxManager{})
+		_, err := service.ReverseWithdraw(ctx, orderNumber)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawAlreadyReversed, appErr.Code)
+	})
+
+	t.Run("returns not found for unknown order number", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(nil, nil)
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, nil, nil, passThroughTxManager{})
+		_, err := service.ReverseWithdraw(ctx, orderNumber)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawNotFound, appErr.Code)
+	})
+
+	t.Run("reverses withdrawal only once", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		userLocker := new(MockUserLocker)
+		outboxRecorder := new(MockOutboxRecorder)
+		withdrawID := uuid.New()
+
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(&business.WithdrawDetail{
+			ID: withdrawID, UserID: userID, OrderNumber: orderNumber, Sum: 120, Status: entity.WithdrawStatusCompleted,
+		}, nil).Once()
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(&business.WithdrawDetail{
+			ID: withdrawID, UserID: userID, OrderNumber: orderNumber, Sum: 120, Status: entity.WithdrawStatusReversed,
+		}, nil).Once()
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("Reverse", ctx, withdrawID, mock.Anything).Return(true, nil).Once()
+		outboxRecorder.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, userLocker, outboxRecorder, passThroughTxManager{})
+		_, err := service.ReverseWithdraw(ctx, orderNumber)
+		require.NoError(t, err)
+
+		_, err = service.ReverseWithdraw(ctx, orderNumber)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawAlreadyReversed, appErr.Code)
+		withdrawRepository.AssertNumberOfCalls(t, "Reverse", 1)
+		outboxRecorder.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("rejects withdrawal reversed concurrently", func(t *testing.T) {
+		withdrawRepository := new(MockWithdrawRepository)
+		userLocker := new(MockUserLocker)
+		withdrawID := uuid.New()
+
+		withdrawRepository.On("FindByOrderNumber", ctx, orderNumber).Return(&business.WithdrawDetail{
+			ID: withdrawID, UserID: userID, Status: entity.WithdrawStatusCompleted,
+		}, nil)
+		userLocker.On("LockByID", ctx, userID).Return(true, nil)
+		withdrawRepository.On("Reverse", ctx, withdrawID, mock.Anything).Return(false, nil)
+
+		service := NewWithdrawService(withdrawRepository, nil, nil, userLocker, nil, passThroughTxManager{})
+		_, err := service.ReverseWithdraw(ctx, orderNumber)
+
+		var appErr *errs.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, errs.WithdrawAlreadyReversed, appErr.Code)
+	})
+}